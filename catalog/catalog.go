@@ -0,0 +1,102 @@
+// Package catalog generates the documentation of the registered errors.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+// Entry is a row in the error catalog.
+type Entry struct {
+	Kind       errcodes.Kind `json:"kind"`
+	Code       errcodes.Code `json:"code"`
+	Message    string        `json:"message"`
+	HTTPStatus int           `json:"http_status"`
+	GRPCCode   string        `json:"grpc_code"`
+}
+
+// Entries returns the catalog entries for all errors in the registry,
+// sorted by code.
+func Entries(r *errcodes.Registry, opts ...Option) []Entry {
+	o := newOptions(opts)
+	errs := r.All()
+
+	res := make([]Entry, len(errs))
+	for i, err := range errs {
+		res[i] = Entry{
+			Kind:       err.Kind(),
+			Code:       err.Code(),
+			Message:    err.Message(),
+			HTTPStatus: o.mapper.HTTPStatusCode(err),
+			GRPCCode:   o.mapper.GRPCCode(err).String(),
+		}
+	}
+
+	return res
+}
+
+// WriteMarkdown writes the catalog as a Markdown table.
+func WriteMarkdown(w io.Writer, r *errcodes.Registry, opts ...Option) error {
+	var sb strings.Builder
+	sb.WriteString("| Kind | Code | Message | HTTP Status | gRPC Code |\n")
+	sb.WriteString("| ---- | ---- | ------- | ----------- | --------- |\n")
+
+	for _, e := range Entries(r, opts...) {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %d | %s |\n",
+			e.Kind,
+			escapeMarkdown(string(e.Code)),
+			escapeMarkdown(e.Message),
+			e.HTTPStatus,
+			e.GRPCCode,
+		)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteJSON writes the catalog as a JSON array.
+func WriteJSON(w io.Writer, r *errcodes.Registry, opts ...Option) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(Entries(r, opts...))
+}
+
+var htmlTemplate = template.Must(template.New("catalog").Parse(`<table>
+  <thead>
+    <tr>
+      <th>Kind</th>
+      <th>Code</th>
+      <th>Message</th>
+      <th>HTTP Status</th>
+      <th>gRPC Code</th>
+    </tr>
+  </thead>
+  <tbody>
+{{- range .}}
+    <tr>
+      <td>{{.Kind}}</td>
+      <td>{{.Code}}</td>
+      <td>{{.Message}}</td>
+      <td>{{.HTTPStatus}}</td>
+      <td>{{.GRPCCode}}</td>
+    </tr>
+{{- end}}
+  </tbody>
+</table>
+`))
+
+// WriteHTML writes the catalog as a HTML table.
+func WriteHTML(w io.Writer, r *errcodes.Registry, opts ...Option) error {
+	return htmlTemplate.Execute(w, Entries(r, opts...))
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,100 @@
+package catalog_test
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/alextanhongpin/errcodes"
+	"github.com/alextanhongpin/errcodes/catalog"
+)
+
+func newRegistry() *errcodes.Registry {
+	r := errcodes.NewRegistry()
+	r.MustRegister(errcodes.Rehydrate(errcodes.Exists, "user_exists", "The user account already exists").(*errcodes.Error))
+	r.MustRegister(errcodes.Rehydrate(errcodes.BadRequest, "email_invalid", "The email | address is invalid").(*errcodes.Error))
+	return r
+}
+
+func ExampleWriteMarkdown() {
+	if err := catalog.WriteMarkdown(os.Stdout, newRegistry()); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// | Kind | Code | Message | HTTP Status | gRPC Code |
+	// | ---- | ---- | ------- | ----------- | --------- |
+	// | bad_request | email_invalid | The email \| address is invalid | 400 | InvalidArgument |
+	// | exists | user_exists | The user account already exists | 409 | AlreadyExists |
+}
+
+func ExampleWithMapper() {
+	m := errcodes.NewMapper().SetCodeHTTPStatus("email_invalid", http.StatusUnprocessableEntity)
+	if err := catalog.WriteMarkdown(os.Stdout, newRegistry(), catalog.WithMapper(m)); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// | Kind | Code | Message | HTTP Status | gRPC Code |
+	// | ---- | ---- | ------- | ----------- | --------- |
+	// | bad_request | email_invalid | The email \| address is invalid | 422 | InvalidArgument |
+	// | exists | user_exists | The user account already exists | 409 | AlreadyExists |
+}
+
+func ExampleWriteJSON() {
+	if err := catalog.WriteJSON(os.Stdout, newRegistry()); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// [
+	//   {
+	//     "kind": "bad_request",
+	//     "code": "email_invalid",
+	//     "message": "The email | address is invalid",
+	//     "http_status": 400,
+	//     "grpc_code": "InvalidArgument"
+	//   },
+	//   {
+	//     "kind": "exists",
+	//     "code": "user_exists",
+	//     "message": "The user account already exists",
+	//     "http_status": 409,
+	//     "grpc_code": "AlreadyExists"
+	//   }
+	// ]
+}
+
+func ExampleWriteHTML() {
+	if err := catalog.WriteHTML(os.Stdout, newRegistry()); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// <table>
+	//   <thead>
+	//     <tr>
+	//       <th>Kind</th>
+	//       <th>Code</th>
+	//       <th>Message</th>
+	//       <th>HTTP Status</th>
+	//       <th>gRPC Code</th>
+	//     </tr>
+	//   </thead>
+	//   <tbody>
+	//     <tr>
+	//       <td>bad_request</td>
+	//       <td>email_invalid</td>
+	//       <td>The email | address is invalid</td>
+	//       <td>400</td>
+	//       <td>InvalidArgument</td>
+	//     </tr>
+	//     <tr>
+	//       <td>exists</td>
+	//       <td>user_exists</td>
+	//       <td>The user account already exists</td>
+	//       <td>409</td>
+	//       <td>AlreadyExists</td>
+	//     </tr>
+	//   </tbody>
+	// </table>
+}
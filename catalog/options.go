@@ -0,0 +1,30 @@
+package catalog
+
+import "github.com/alextanhongpin/errcodes"
+
+// Option configures the generation of the catalog.
+type Option func(*options)
+
+type options struct {
+	mapper *errcodes.Mapper
+}
+
+// WithMapper sets the mapper used to document the HTTP status and gRPC
+// codes, so that the catalog matches what is served.
+// Defaults to errcodes.DefaultMapper.
+func WithMapper(m *errcodes.Mapper) Option {
+	return func(o *options) {
+		o.mapper = m
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		mapper: errcodes.DefaultMapper,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
@@ -0,0 +1,105 @@
+package errcodes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"net"
+	"sync"
+)
+
+// Classifier returns the kind of the error, or false if the error is not
+// recognized.
+type Classifier func(err error) (Kind, bool)
+
+var classifiers struct {
+	mu  sync.RWMutex
+	fns []Classifier
+}
+
+// RegisterClassifier registers a classifier for third-party error types.
+// Classifiers are tried in the order they are registered, before the
+// classifier for the standard library errors.
+func RegisterClassifier(fn Classifier) {
+	classifiers.mu.Lock()
+	classifiers.fns = append(classifiers.fns, fn)
+	classifiers.mu.Unlock()
+}
+
+// Classify returns the kind of the error.
+// The kind of the domain error in the error chain takes precedence,
+// followed by the registered classifiers and the standard library errors.
+// It returns Unknown if the error is not recognized.
+func Classify(err error) Kind {
+	if err == nil {
+		return Unknown
+	}
+
+	var ec *Error
+	if errors.As(err, &ec) {
+		return ec.kind
+	}
+
+	classifiers.mu.RLock()
+	fns := classifiers.fns
+	classifiers.mu.RUnlock()
+
+	for _, fn := range fns {
+		if kind, ok := fn(err); ok && kind.Valid() {
+			return kind
+		}
+	}
+
+	if kind, ok := classifyStdlib(err); ok {
+		return kind
+	}
+
+	return Unknown
+}
+
+func classifyStdlib(err error) (Kind, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded, true
+	case errors.Is(err, fs.ErrNotExist):
+		return NotFound, true
+	case errors.Is(err, fs.ErrExist):
+		return Exists, true
+	case errors.Is(err, fs.ErrPermission):
+		return Forbidden, true
+	case errors.Is(err, sql.ErrNoRows):
+		return NotFound, true
+	}
+
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return DeadlineExceeded, true
+	}
+
+	return "", false
+}
+
+// FromError returns the domain error in the error chain, or wraps the error
+// in an *Error with the classified kind.
+// The message is the description of the kind, so that the cause is never
+// exposed to the client.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var ec *Error
+	if errors.As(err, &ec) {
+		return err
+	}
+
+	kind := Classify(err)
+	return &Error{
+		kind:    kind,
+		message: kind.Description(),
+		cause:   err,
+	}
+}
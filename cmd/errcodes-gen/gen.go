@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/alextanhongpin/errcodes"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the declarative list of errors.
+// Since JSON is a subset of YAML, the spec can be written in either format.
+type Spec struct {
+	Package string      `yaml:"package"`
+	Errors  []ErrorSpec `yaml:"errors"`
+}
+
+// ErrorSpec is the declaration of a single sentinel error.
+// If Params is set, an errcodes.Template is generated next to the sentinel,
+// rendering the params into the Template message, e.g. "The user {{.ID}}
+// already exists".
+type ErrorSpec struct {
+	Name     string   `yaml:"name"`
+	Kind     string   `yaml:"kind"`
+	Code     string   `yaml:"code"`
+	Message  string   `yaml:"message"`
+	Template string   `yaml:"template"`
+	Params   []string `yaml:"params"`
+}
+
+// TemplateName returns the name of the generated template, which is the
+// name of the sentinel without the Err prefix.
+func (e ErrorSpec) TemplateName() string {
+	if name := strings.TrimPrefix(e.Name, "Err"); name != e.Name && token.IsExported(name) {
+		return name
+	}
+
+	return e.Name + "Template"
+}
+
+var kindNames = map[errcodes.Kind]string{
+	errcodes.Aborted:            "Aborted",
+	errcodes.BadRequest:         "BadRequest",
+	errcodes.Canceled:           "Canceled",
+	errcodes.Conflict:           "Conflict",
+	errcodes.DataLoss:           "DataLoss",
+	errcodes.DeadlineExceeded:   "DeadlineExceeded",
+	errcodes.Exists:             "Exists",
+	errcodes.Forbidden:          "Forbidden",
+	errcodes.Internal:           "Internal",
+	errcodes.NotFound:           "NotFound",
+	errcodes.NotImplemented:     "NotImplemented",
+	errcodes.OutOfRange:         "OutOfRange",
+	errcodes.PreconditionFailed: "PreconditionFailed",
+	errcodes.TooManyRequests:    "TooManyRequests",
+	errcodes.Unauthorized:       "Unauthorized",
+	errcodes.Unavailable:        "Unavailable",
+	errcodes.Unknown:            "Unknown",
+}
+
+func parseSpec(b []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(b, &spec); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// validate checks the spec at generate time, instead of letting
+// errcodes.New panic at init.
+func (s *Spec) validate() error {
+	var errs []error
+	if !token.IsIdentifier(s.Package) {
+		errs = append(errs, fmt.Errorf("invalid package name %q", s.Package))
+	}
+
+	names := make(map[string]bool)
+	codes := make(map[string]bool)
+	for i, e := range s.Errors {
+		if !token.IsIdentifier(e.Name) || !token.IsExported(e.Name) {
+			errs = append(errs, fmt.Errorf("errors[%d]: invalid name %q", i, e.Name))
+		}
+		if names[e.Name] {
+			errs = append(errs, fmt.Errorf("errors[%d]: duplicate name %q", i, e.Name))
+		}
+		names[e.Name] = true
+
+		if !errcodes.Kind(e.Kind).Valid() {
+			errs = append(errs, fmt.Errorf("errors[%d]: %w: %q", i, errcodes.ErrInvalidKind, e.Kind))
+		}
+
+		if e.Code == "" {
+			errs = append(errs, fmt.Errorf("errors[%d]: missing code", i))
+		}
+		if codes[e.Code] {
+			errs = append(errs, fmt.Errorf("errors[%d]: duplicate code %q", i, e.Code))
+		}
+		codes[e.Code] = true
+
+		if len(e.Params) == 0 && e.Template == "" {
+			continue
+		}
+
+		for _, name := range []string{e.TemplateName(), e.TemplateName() + "Params"} {
+			if names[name] {
+				errs = append(errs, fmt.Errorf("errors[%d]: duplicate name %q", i, name))
+			}
+			names[name] = true
+		}
+
+		for _, err := range validateTemplate(e) {
+			errs = append(errs, fmt.Errorf("errors[%d]: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateTemplate checks that the placeholders in the template match the
+// params.
+func validateTemplate(e ErrorSpec) []error {
+	if e.Template == "" {
+		return []error{errors.New("missing template for params")}
+	}
+	if len(e.Params) == 0 {
+		return []error{errors.New("missing params for template")}
+	}
+
+	var errs []error
+	params := make(map[string]bool)
+	for _, p := range e.Params {
+		if !token.IsIdentifier(p) || !token.IsExported(p) {
+			errs = append(errs, fmt.Errorf("invalid param %q", p))
+		}
+		if params[p] {
+			errs = append(errs, fmt.Errorf("duplicate param %q", p))
+		}
+		params[p] = true
+	}
+
+	placeholders, err := templateFields(e.Template)
+	if err != nil {
+		return append(errs, fmt.Errorf("invalid template: %w", err))
+	}
+
+	for _, p := range placeholders {
+		if !params[p] {
+			errs = append(errs, fmt.Errorf("unknown placeholder %q", p))
+		}
+	}
+	for _, p := range e.Params {
+		if params[p] && !contains(placeholders, p) {
+			errs = append(errs, fmt.Errorf("unused param %q", p))
+		}
+	}
+
+	return errs
+}
+
+// templateFields returns the sorted fields referenced by the template,
+// e.g. "ID" for {{.ID}}.
+func templateFields(text string) ([]string, error) {
+	// The functions are checked by text/template when the template is
+	// created at init.
+	tree := parse.New("message")
+	tree.Mode = parse.SkipFuncCheck
+	trees := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(text, "", "", trees); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var walk func(n parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Cmds {
+				walk(c)
+			}
+		case *parse.CommandNode:
+			for _, c := range n.Args {
+				walk(c)
+			}
+		case *parse.FieldNode:
+			seen[n.Ident[0]] = true
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	for _, tree := range trees {
+		walk(tree.Root)
+	}
+
+	res := make([]string, 0, len(seen))
+	for f := range seen {
+		res = append(res, f)
+	}
+	sort.Strings(res)
+
+	return res, nil
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"kind": func(k string) string {
+		return kindNames[errcodes.Kind(k)]
+	},
+}).Parse(`// Code generated by errcodes-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import "github.com/alextanhongpin/errcodes"
+
+var (
+{{- range .Errors}}
+	{{.Name}} = errcodes.New(errcodes.{{kind .Kind}}, {{printf "%q" .Code}}, {{printf "%q" .Message}})
+{{- end}}
+)
+{{- range .Errors}}
+{{- if .Params}}
+
+// {{.TemplateName}}Params are the params of the {{.TemplateName}} template.
+type {{.TemplateName}}Params struct {
+{{- range .Params}}
+	{{.}} string
+{{- end}}
+}
+
+// {{.TemplateName}} renders the params into the message of {{.Name}}.
+var {{.TemplateName}} = errcodes.NewTemplate[{{.TemplateName}}Params]({{.Name}}, {{printf "%q" .Template}})
+{{- end}}
+{{- end}}
+`))
+
+func generate(spec *Spec) ([]byte, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, spec); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
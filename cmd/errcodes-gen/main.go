@@ -0,0 +1,61 @@
+// Command errcodes-gen generates sentinel errors from a YAML or JSON spec.
+//
+// Usage:
+//
+//	//go:generate go run github.com/alextanhongpin/errcodes/cmd/errcodes-gen -in errors.yaml -out errors_gen.go
+//
+// The spec lists the errors to declare:
+//
+//	package: users
+//	errors:
+//	  - name: ErrUserExists
+//	    kind: exists
+//	    code: user_exists
+//	    message: The user account already exists
+//	    template: The user {{.ID}} already exists
+//	    params: [ID]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	in := flag.String("in", "", "path to the YAML or JSON spec")
+	out := flag.String("out", "errors_gen.go", "path to the generated Go file")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file, overrides the spec")
+	flag.Parse()
+
+	if err := run(*in, *out, *pkg); err != nil {
+		fmt.Fprintf(os.Stderr, "errcodes-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pkg string) error {
+	if in == "" {
+		return fmt.Errorf("missing -in flag")
+	}
+
+	b, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+
+	spec, err := parseSpec(b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+	if pkg != "" {
+		spec.Package = pkg
+	}
+
+	src, err := generate(spec)
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+
+	return os.WriteFile(out, src, 0o644)
+}
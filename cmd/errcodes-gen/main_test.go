@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+func TestGenerate(t *testing.T) {
+	spec, err := parseSpec([]byte(`
+package: users
+errors:
+  - name: ErrUserExists
+    kind: exists
+    code: user_exists
+    message: The user account already exists
+    template: The user {{.ID}} already exists
+    params: [ID]
+  - name: ErrUserNotFound
+    kind: not_found
+    code: user_not_found
+    message: The user "account" does not exist
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `// Code generated by errcodes-gen. DO NOT EDIT.
+
+package users
+
+import "github.com/alextanhongpin/errcodes"
+
+var (
+	ErrUserExists   = errcodes.New(errcodes.Exists, "user_exists", "The user account already exists")
+	ErrUserNotFound = errcodes.New(errcodes.NotFound, "user_not_found", "The user \"account\" does not exist")
+)
+
+// UserExistsParams are the params of the UserExists template.
+type UserExistsParams struct {
+	ID string
+}
+
+// UserExists renders the params into the message of ErrUserExists.
+var UserExists = errcodes.NewTemplate[UserExistsParams](ErrUserExists, "The user {{.ID}} already exists")
+`
+	if got := string(b); want != got {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestGenerateJSON(t *testing.T) {
+	spec, err := parseSpec([]byte(`{
+	"package": "users",
+	"errors": [
+		{"name": "ErrUserExists", "kind": "exists", "code": "user_exists", "message": "The user account already exists"}
+	]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := generate(spec); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	spec, err := parseSpec([]byte(`
+package: users
+errors:
+  - name: ErrUserExists
+    kind: exist
+    code: user_exists
+  - name: ErrUserExists
+    kind: exists
+    code: user_exists
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = generate(spec)
+	if !errors.Is(err, errcodes.ErrInvalidKind) {
+		t.Fatalf("want %v, got %v", errcodes.ErrInvalidKind, err)
+	}
+
+	want := `errors[0]: errcodes: invalid kind: "exist"
+errors[1]: duplicate name "ErrUserExists"
+errors[1]: duplicate code "user_exists"`
+	if got := err.Error(); want != got {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestGenerateInvalidTemplate(t *testing.T) {
+	spec, err := parseSpec([]byte(`
+package: users
+errors:
+  - name: ErrUserExists
+    kind: exists
+    code: user_exists
+    template: The user {{.ID}} already exists in {{.Org}}
+    params: [ID, Email, id]
+  - name: ErrUserNotFound
+    kind: not_found
+    code: user_not_found
+    params: [ID]
+  - name: ErrUserBanned
+    kind: forbidden
+    code: user_banned
+    template: The user {{.ID}} is banned
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = generate(spec)
+
+	want := `errors[0]: invalid param "id"
+errors[0]: unknown placeholder "Org"
+errors[0]: unused param "Email"
+errors[0]: unused param "id"
+errors[1]: missing template for params
+errors[2]: missing params for template`
+	if got := err.Error(); want != got {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,15 @@
+// Command errcodes-lint checks errcodes declarations.
+//
+// Usage:
+//
+//	go vet -vettool=$(which errcodes-lint) ./...
+package main
+
+import (
+	"github.com/alextanhongpin/errcodes/lint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(lint.Analyzer)
+}
@@ -0,0 +1,393 @@
+package errcodes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Detail is the structured detail of an error, modeled on
+// google.rpc.errdetails.
+type Detail interface {
+	proto() proto.Message
+}
+
+// FieldViolations describes the fields of a bad request.
+type FieldViolations []FieldViolation
+
+// FieldViolation describes a single bad request field.
+// The Code is not part of google.rpc.BadRequest, so it is carried in the
+// google.rpc.ErrorInfo metadata over gRPC, and as an extra "code" member in
+// the JSON details.
+type FieldViolation struct {
+	Field       string
+	Code        Code
+	Description string
+}
+
+func (d FieldViolations) proto() proto.Message {
+	pb := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(d)),
+	}
+	for i, v := range d {
+		pb.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		}
+	}
+
+	return pb
+}
+
+func (d FieldViolations) marshalJSON() ([]byte, error) {
+	br := badRequestJSON{Type: badRequestType}
+	for _, v := range d {
+		br.FieldViolations = append(br.FieldViolations, fieldViolationJSON{
+			Field:       v.Field,
+			Description: v.Description,
+			Code:        v.Code,
+		})
+	}
+
+	return json.Marshal(br)
+}
+
+// RetryInfo describes when the client can retry a failed request.
+type RetryInfo struct {
+	RetryDelay time.Duration
+}
+
+func (d RetryInfo) proto() proto.Message {
+	return &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(d.RetryDelay),
+	}
+}
+
+// QuotaFailure describes how a quota check failed.
+type QuotaFailure struct {
+	Violations []QuotaViolation
+}
+
+// QuotaViolation describes a single quota violation.
+type QuotaViolation struct {
+	Subject     string
+	Description string
+}
+
+func (d QuotaFailure) proto() proto.Message {
+	pb := &errdetails.QuotaFailure{
+		Violations: make([]*errdetails.QuotaFailure_Violation, len(d.Violations)),
+	}
+	for i, v := range d.Violations {
+		pb.Violations[i] = &errdetails.QuotaFailure_Violation{
+			Subject:     v.Subject,
+			Description: v.Description,
+		}
+	}
+
+	return pb
+}
+
+// PreconditionFailure describes what preconditions have failed.
+type PreconditionFailure struct {
+	Violations []PreconditionViolation
+}
+
+// PreconditionViolation describes a single precondition failure.
+type PreconditionViolation struct {
+	Type        string
+	Subject     string
+	Description string
+}
+
+func (d PreconditionFailure) proto() proto.Message {
+	pb := &errdetails.PreconditionFailure{
+		Violations: make([]*errdetails.PreconditionFailure_Violation, len(d.Violations)),
+	}
+	for i, v := range d.Violations {
+		pb.Violations[i] = &errdetails.PreconditionFailure_Violation{
+			Type:        v.Type,
+			Subject:     v.Subject,
+			Description: v.Description,
+		}
+	}
+
+	return pb
+}
+
+// ResourceInfo describes the resource that is being accessed.
+type ResourceInfo struct {
+	ResourceType string
+	ResourceName string
+	Owner        string
+	Description  string
+}
+
+func (d ResourceInfo) proto() proto.Message {
+	return &errdetails.ResourceInfo{
+		ResourceType: d.ResourceType,
+		ResourceName: d.ResourceName,
+		Owner:        d.Owner,
+		Description:  d.Description,
+	}
+}
+
+// Help provides links to documentation for the error.
+type Help struct {
+	Links []Link
+}
+
+// Link describes a single documentation link.
+type Link struct {
+	Description string
+	URL         string
+}
+
+func (d Help) proto() proto.Message {
+	pb := &errdetails.Help{
+		Links: make([]*errdetails.Help_Link, len(d.Links)),
+	}
+	for i, l := range d.Links {
+		pb.Links[i] = &errdetails.Help_Link{
+			Description: l.Description,
+			Url:         l.URL,
+		}
+	}
+
+	return pb
+}
+
+// detailFromProto returns the detail for the google.rpc.errdetails message.
+func detailFromProto(m any) (Detail, bool) {
+	switch pb := m.(type) {
+	case *errdetails.BadRequest:
+		d := make(FieldViolations, len(pb.GetFieldViolations()))
+		for i, v := range pb.GetFieldViolations() {
+			d[i] = FieldViolation{
+				Field:       v.GetField(),
+				Description: v.GetDescription(),
+			}
+		}
+
+		return d, true
+	case *errdetails.RetryInfo:
+		return RetryInfo{
+			RetryDelay: pb.GetRetryDelay().AsDuration(),
+		}, true
+	case *errdetails.QuotaFailure:
+		d := QuotaFailure{
+			Violations: make([]QuotaViolation, len(pb.GetViolations())),
+		}
+		for i, v := range pb.GetViolations() {
+			d.Violations[i] = QuotaViolation{
+				Subject:     v.GetSubject(),
+				Description: v.GetDescription(),
+			}
+		}
+
+		return d, true
+	case *errdetails.PreconditionFailure:
+		d := PreconditionFailure{
+			Violations: make([]PreconditionViolation, len(pb.GetViolations())),
+		}
+		for i, v := range pb.GetViolations() {
+			d.Violations[i] = PreconditionViolation{
+				Type:        v.GetType(),
+				Subject:     v.GetSubject(),
+				Description: v.GetDescription(),
+			}
+		}
+
+		return d, true
+	case *errdetails.ResourceInfo:
+		return ResourceInfo{
+			ResourceType: pb.GetResourceType(),
+			ResourceName: pb.GetResourceName(),
+			Owner:        pb.GetOwner(),
+			Description:  pb.GetDescription(),
+		}, true
+	case *errdetails.Help:
+		d := Help{
+			Links: make([]Link, len(pb.GetLinks())),
+		}
+		for i, l := range pb.GetLinks() {
+			d.Links[i] = Link{
+				Description: l.GetDescription(),
+				URL:         l.GetUrl(),
+			}
+		}
+
+		return d, true
+	default:
+		return nil, false
+	}
+}
+
+// fieldViolationCodeKey returns the google.rpc.ErrorInfo metadata key of
+// the code of the n-th field violation across all FieldViolations details.
+func fieldViolationCodeKey(n int) string {
+	return fmt.Sprintf("field_violations.%d.code", n)
+}
+
+// fieldViolationCodes returns the google.rpc.ErrorInfo metadata for the
+// codes of the field violations.
+func (d Details) fieldViolationCodes() map[string]string {
+	res := make(map[string]string)
+
+	var n int
+	for _, detail := range d {
+		fvs, ok := detail.(FieldViolations)
+		if !ok {
+			continue
+		}
+
+		for _, v := range fvs {
+			if v.Code != "" {
+				res[fieldViolationCodeKey(n)] = string(v.Code)
+			}
+			n++
+		}
+	}
+
+	return res
+}
+
+// setFieldViolationCodes sets the codes of the field violations from the
+// google.rpc.ErrorInfo metadata.
+func (d Details) setFieldViolationCodes(metadata map[string]string) {
+	var n int
+	for _, detail := range d {
+		fvs, ok := detail.(FieldViolations)
+		if !ok {
+			continue
+		}
+
+		for i := range fvs {
+			fvs[i].Code = Code(metadata[fieldViolationCodeKey(n)])
+			n++
+		}
+	}
+}
+
+// Details is a list of details that marshals to JSON in the same shape as
+// the google.rpc.Status details, e.g.
+//
+//	[{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "1s"}]
+type Details []Detail
+
+// badRequestJSON is the JSON of google.rpc.BadRequest, with the code of
+// the field violations.
+type badRequestJSON struct {
+	Type            string               `json:"@type"`
+	FieldViolations []fieldViolationJSON `json:"fieldViolations,omitempty"`
+}
+
+type fieldViolationJSON struct {
+	Field       string `json:"field,omitempty"`
+	Description string `json:"description,omitempty"`
+	Code        Code   `json:"code,omitempty"`
+}
+
+var badRequestType = "type.googleapis.com/" + string((&errdetails.BadRequest{}).ProtoReflect().Descriptor().FullName())
+
+// MarshalJSON satisfies the json.Marshaler interface.
+func (d Details) MarshalJSON() ([]byte, error) {
+	res := make([]json.RawMessage, len(d))
+	for i, detail := range d {
+		if fvs, ok := detail.(FieldViolations); ok {
+			b, err := fvs.marshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			res[i] = b
+
+			continue
+		}
+
+		a, err := anypb.New(detail.proto())
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := protojson.Marshal(a)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = b
+	}
+
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+// Unknown detail types are skipped.
+func (d *Details) UnmarshalJSON(b []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
+		return err
+	}
+
+	res := make(Details, 0, len(raws))
+	for _, raw := range raws {
+		var br badRequestJSON
+		if err := json.Unmarshal(raw, &br); err == nil && br.Type == badRequestType {
+			fvs := make(FieldViolations, len(br.FieldViolations))
+			for i, v := range br.FieldViolations {
+				fvs[i] = FieldViolation{
+					Field:       v.Field,
+					Code:        v.Code,
+					Description: v.Description,
+				}
+			}
+			res = append(res, fvs)
+
+			continue
+		}
+
+		var a anypb.Any
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(raw, &a); err != nil {
+			continue
+		}
+
+		m, err := a.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+
+		if detail, ok := detailFromProto(m); ok {
+			res = append(res, detail)
+		}
+	}
+	*d = res
+
+	return nil
+}
+
+// Details returns the structured details of the error.
+func (e *Error) Details() Details {
+	return e.details
+}
+
+// WithDetails returns a copy of the domain error in the error chain with
+// the details appended.
+// The sentinel is never mutated.
+// Like WithInternal, the outer error chain is kept.
+// If there is no domain error, the error is returned as it is.
+func WithDetails(err error, details ...Detail) error {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return err
+	}
+
+	res := *ec
+	res.details = append(append(Details(nil), ec.details...), details...)
+
+	return replace(err, &res)
+}
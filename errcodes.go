@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"google.golang.org/grpc/codes"
 )
@@ -34,7 +35,21 @@ const (
 	Unknown            Kind = "unknown"
 )
 
+// Valid returns true if the kind is a built-in kind, or a custom kind
+// registered with RegisterKind.
 func (c Kind) Valid() bool {
+	if c.builtin() {
+		return true
+	}
+
+	kindsMu.RLock()
+	_, ok := kindInfoByKind[c]
+	kindsMu.RUnlock()
+
+	return ok
+}
+
+func (c Kind) builtin() bool {
 	switch c {
 	case
 		Aborted,
@@ -64,14 +79,42 @@ type Error struct {
 	kind    Kind
 	code    Code
 	message string
+
+	// internal is the developer message, which is never exposed to the
+	// client.
+	internal string
+	params   any
+	details  Details
+	cause    error
 }
 
 // New returns a new error with the given code, reason and description.
+// The error is registered into the DefaultRegistry, and New panics if the
+// code conflicts with a previously declared error.
 func New(kind Kind, code Code, message string) error {
 	if !kind.Valid() {
 		panic(ErrInvalidKind)
 	}
 
+	err := &Error{
+		kind:    kind,
+		code:    code,
+		message: message,
+	}
+	DefaultRegistry.MustRegister(err)
+
+	return err
+}
+
+// Rehydrate returns the error received from another service, e.g. over
+// HTTP or gRPC.
+// Unlike New, the error is not registered, and invalid kind defaults to
+// Unknown.
+func Rehydrate(kind Kind, code Code, message string) error {
+	if !kind.Valid() {
+		kind = Unknown
+	}
+
 	return &Error{
 		kind:    kind,
 		code:    code,
@@ -80,8 +123,22 @@ func New(kind Kind, code Code, message string) error {
 }
 
 // Error satisfies the error interface.
+// Unlike Message, it includes the internal message and the cause.
 func (e *Error) Error() string {
-	return e.message
+	msg := e.message
+	if e.internal != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.internal)
+	}
+	if e.cause != nil {
+		msg = fmt.Sprintf("%s: %s", msg, e.cause)
+	}
+
+	return msg
+}
+
+// Unwrap returns the cause of the error, if any.
+func (e *Error) Unwrap() error {
+	return e.cause
 }
 
 func (e *Error) Kind() Kind {
@@ -92,11 +149,116 @@ func (e *Error) Code() Code {
 	return e.code
 }
 
+// Message returns the public message of the error, which is safe to show
+// to the client.
 func (e *Error) Message() string {
 	return e.message
 }
 
+// Internal returns the internal message of the error, if any.
+func (e *Error) Internal() string {
+	return e.internal
+}
+
+// Params returns the params the message is rendered from, if the error is
+// created from a Template.
+func (e *Error) Params() any {
+	return e.params
+}
+
+// withMessage returns a copy of the error with the given message, leaving
+// the sentinel untouched.
+func (e *Error) withMessage(message string) *Error {
+	err := *e
+	err.message = message
+	return &err
+}
+
+// WithInternal returns a copy of the domain error in the error chain with
+// the internal message, e.g. to describe what went wrong to the developer.
+// The internal message is included in Error and String, but never exposed
+// over HTTP or gRPC.
+// If the domain error is wrapped, the outer error chain, e.g. the stack
+// trace, is kept, and errors.As returns the copy.
+// If there is no domain error, the error is returned as it is.
+func WithInternal(err error, format string, args ...any) error {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return err
+	}
+
+	res := *ec
+	res.internal = fmt.Sprintf(format, args...)
+
+	return replace(err, &res)
+}
+
+// WithCause returns a copy of the domain error in the error chain with the
+// given cause.
+// Like the internal message, the cause is never exposed over HTTP or gRPC.
+// Like WithInternal, the outer error chain is kept.
+// If there is no domain error, the error is returned as it is.
+func WithCause(err, cause error) error {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return err
+	}
+
+	res := *ec
+	res.cause = cause
+
+	return replace(err, &res)
+}
+
+// replace returns the copy of the domain error in place of the domain error
+// in the error chain of err.
+func replace(err error, ec *Error) error {
+	if _, ok := err.(*Error); ok {
+		return ec
+	}
+
+	return &replacedError{err: err, ec: ec}
+}
+
+// replacedError keeps the outer error chain of a domain error that is
+// replaced by a copy.
+type replacedError struct {
+	err error
+	ec  *Error
+}
+
+// Error returns the error message of the copy.
+func (e *replacedError) Error() string {
+	return e.ec.Error()
+}
+
+// Unwrap returns the outer error chain, e.g. to find the stack trace.
+func (e *replacedError) Unwrap() error {
+	return e.err
+}
+
+// Is checks the copy, before the outer error chain is unwrapped.
+func (e *replacedError) Is(err error) bool {
+	return errors.Is(e.ec, err)
+}
+
+// As allows errors.As to find the copy instead of the replaced domain
+// error.
+func (e *replacedError) As(target any) bool {
+	t, ok := target.(**Error)
+	if !ok {
+		return false
+	}
+	*t = e.ec
+
+	return true
+}
+
 func (e *Error) String() string {
+	if e.internal != "" {
+		return fmt.Sprintf("%s/%s: %s: %s", e.kind, e.code, e.message, e.internal)
+	}
+
 	return fmt.Sprintf("%s/%s: %s", e.kind, e.code, e.message)
 }
 
@@ -110,6 +272,119 @@ func (e *Error) Is(err error) bool {
 	return e.kind == ec.kind && e.code == ec.code
 }
 
+// Severity is the severity of the error, e.g. to decide whether to alert or
+// log at warn.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+type kindInfo struct {
+	description string
+	retryable   bool
+	serverFault bool
+	severity    Severity
+}
+
+var kindInfoByKind = map[Kind]kindInfo{
+	Aborted:            {"The operation was aborted due to a concurrency conflict", true, false, SeverityWarning},
+	BadRequest:         {"The request is invalid", false, false, SeverityInfo},
+	Canceled:           {"The operation was cancelled by the caller", false, false, SeverityInfo},
+	Conflict:           {"The request conflicts with the current state of the resource", false, false, SeverityInfo},
+	DataLoss:           {"Unrecoverable data loss or corruption", false, true, SeverityCritical},
+	DeadlineExceeded:   {"The deadline expired before the operation could complete", true, true, SeverityWarning},
+	Exists:             {"The resource already exists", false, false, SeverityInfo},
+	Forbidden:          {"The caller does not have permission to perform the operation", false, false, SeverityWarning},
+	Internal:           {"An internal error occurred", false, true, SeverityError},
+	NotFound:           {"The resource was not found", false, false, SeverityInfo},
+	NotImplemented:     {"The operation is not implemented", false, true, SeverityError},
+	OutOfRange:         {"The operation was attempted past the valid range", false, false, SeverityInfo},
+	PreconditionFailed: {"The system is not in a state required for the operation", false, false, SeverityInfo},
+	TooManyRequests:    {"The caller has exhausted the rate limit or quota", true, false, SeverityWarning},
+	Unauthorized:       {"The caller is not authenticated", false, false, SeverityWarning},
+	Unavailable:        {"The service is currently unavailable", true, true, SeverityError},
+	Unknown:            {"An unknown error occurred", false, true, SeverityError},
+}
+
+func (c Kind) info() (kindInfo, bool) {
+	kindsMu.RLock()
+	info, ok := kindInfoByKind[c]
+	kindsMu.RUnlock()
+
+	return info, ok
+}
+
+// Kinds returns all the kinds, including custom kinds, sorted by name.
+func Kinds() []Kind {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+
+	res := make([]Kind, 0, len(kindInfoByKind))
+	for k := range kindInfoByKind {
+		res = append(res, k)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res
+}
+
+// Description returns the human description of the kind.
+func (c Kind) Description() string {
+	info, _ := c.info()
+	return info.description
+}
+
+// IsRetryable returns true if the operation can be retried.
+func (c Kind) IsRetryable() bool {
+	info, _ := c.info()
+	return info.retryable
+}
+
+// IsClientFault returns true if the error is caused by the caller.
+func (c Kind) IsClientFault() bool {
+	info, ok := c.info()
+	return ok && !info.serverFault
+}
+
+// IsServerFault returns true if the error is caused by the server.
+// Invalid kinds are treated as server faults.
+func (c Kind) IsServerFault() bool {
+	return !c.IsClientFault()
+}
+
+// Severity returns the severity of the kind.
+// Invalid kinds have error severity.
+func (c Kind) Severity() Severity {
+	info, ok := c.info()
+	if !ok {
+		return SeverityError
+	}
+
+	return info.severity
+}
+
 var httpStatusByKind = map[Kind]int{
 	Aborted:            http.StatusConflict,
 	BadRequest:         http.StatusBadRequest,
@@ -132,7 +407,9 @@ var httpStatusByKind = map[Kind]int{
 
 // ̱HTTPStatusCode returns the HTTP status code for the given error code.
 func HTTPStatusCode(kind Kind) int {
+	kindsMu.RLock()
 	status, ok := httpStatusByKind[kind]
+	kindsMu.RUnlock()
 	if !ok {
 		return http.StatusInternalServerError
 	}
@@ -162,27 +439,113 @@ var grpcCodeByKind = map[Kind]codes.Code{
 
 // GRPCCode returns the gRPC code for the given error code.
 func GRPCCode(kind Kind) codes.Code {
+	kindsMu.RLock()
 	code, ok := grpcCodeByKind[kind]
+	kindsMu.RUnlock()
 	if !ok {
 		return codes.Internal
 	}
 	return code
 }
 
-var kindByGRPCCode = func() map[codes.Code]Kind {
-	m := make(map[codes.Code]Kind)
-	for k, v := range grpcCodeByKind {
-		m[v] = k
-	}
-	return m
-}()
+// kindByGRPCCode is the reverse of grpcCodeByKind.
+// When several kinds map to the same gRPC code, the kind with the canonical
+// meaning of the code wins, e.g. codes.Aborted maps to Aborted, not
+// Conflict.
+var kindByGRPCCode = map[codes.Code]Kind{
+	codes.Aborted:            Aborted,
+	codes.AlreadyExists:      Exists,
+	codes.Canceled:           Canceled,
+	codes.DataLoss:           DataLoss,
+	codes.DeadlineExceeded:   DeadlineExceeded,
+	codes.FailedPrecondition: PreconditionFailed,
+	codes.Internal:           Internal,
+	codes.InvalidArgument:    BadRequest,
+	codes.NotFound:           NotFound,
+	codes.OutOfRange:         OutOfRange,
+	codes.PermissionDenied:   Forbidden,
+	codes.ResourceExhausted:  TooManyRequests,
+	codes.Unauthenticated:    Unauthorized,
+	codes.Unavailable:        Unavailable,
+	codes.Unimplemented:      NotImplemented,
+	codes.Unknown:            Unknown,
+}
 
-// GRPCCodeToHTTP returns the HTTP code for the given grpc code.
-func GRPCCodeToHTTP(code codes.Code) int {
+// KindFromGRPCCode returns the kind for the given gRPC code.
+// When several kinds map to the same gRPC code, the kind with the canonical
+// meaning of the code wins, e.g. codes.Aborted maps to Aborted, not
+// Conflict. Custom kinds are never returned.
+// It returns Unknown for codes.OK and unmapped codes.
+func KindFromGRPCCode(code codes.Code) Kind {
+	kindsMu.RLock()
 	kind, ok := kindByGRPCCode[code]
+	kindsMu.RUnlock()
 	if !ok {
-		return http.StatusInternalServerError
+		return Unknown
 	}
 
-	return HTTPStatusCode(kind)
+	return kind
+}
+
+// kindByHTTPStatus is the reverse of httpStatusByKind.
+// When several kinds map to the same HTTP status, the most general kind
+// wins, e.g. 400 maps to BadRequest, 409 to Conflict and 500 to Internal.
+// It also maps the status codes that are not produced by any kind, but
+// have a fitting kind, e.g. 408 maps to DeadlineExceeded.
+var kindByHTTPStatus = map[int]Kind{
+	http.StatusBadRequest:                   BadRequest,
+	http.StatusUnauthorized:                 Unauthorized,
+	http.StatusForbidden:                    Forbidden,
+	http.StatusNotFound:                     NotFound,
+	http.StatusRequestTimeout:               DeadlineExceeded,
+	http.StatusConflict:                     Conflict,
+	http.StatusPreconditionFailed:           PreconditionFailed,
+	http.StatusRequestedRangeNotSatisfiable: OutOfRange,
+	http.StatusTooManyRequests:              TooManyRequests,
+	499:                                     Canceled, // client closed request.
+	http.StatusInternalServerError:          Internal,
+	http.StatusNotImplemented:               NotImplemented,
+	http.StatusBadGateway:                   Unavailable,
+	http.StatusServiceUnavailable:           Unavailable,
+	http.StatusGatewayTimeout:               DeadlineExceeded,
+}
+
+// KindFromHTTPStatus returns the kind for the given HTTP status.
+// When several kinds map to the same HTTP status, the most general kind
+// wins, e.g. 400 maps to BadRequest, 409 to Conflict and 500 to Internal.
+// Custom kinds only win if no other kind maps to the status.
+// Unmapped status fall back by class, so 4xx maps to BadRequest and 5xx to
+// Internal. It returns Unknown for any other status.
+func KindFromHTTPStatus(status int) Kind {
+	kindsMu.RLock()
+	kind, ok := kindByHTTPStatus[status]
+	kindsMu.RUnlock()
+	if ok {
+		return kind
+	}
+
+	switch {
+	case status >= 400 && status < 500:
+		return BadRequest
+	case status >= 500 && status < 600:
+		return Internal
+	default:
+		return Unknown
+	}
+}
+
+// HTTPStatusToGRPCCode returns the gRPC code for the given HTTP status.
+// Successful 2xx status map to codes.OK.
+func HTTPStatusToGRPCCode(status int) codes.Code {
+	if status >= 200 && status < 300 {
+		return codes.OK
+	}
+
+	return GRPCCode(KindFromHTTPStatus(status))
+}
+
+// GRPCCodeToHTTP returns the HTTP code for the given grpc code.
+// Unmapped codes return 500.
+func GRPCCodeToHTTP(code codes.Code) int {
+	return HTTPStatusCode(KindFromGRPCCode(code))
 }
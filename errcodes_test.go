@@ -3,10 +3,15 @@ package errcodes_test
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alextanhongpin/errcodes"
+	"github.com/alextanhongpin/errcodes/stacktrace"
 	"google.golang.org/grpc/codes"
 )
 
@@ -40,3 +45,354 @@ func TestErrcodes(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistry(t *testing.T) {
+	r := errcodes.NewRegistry()
+
+	userExists := errcodes.Rehydrate(errcodes.Exists, "user_exists", "The user account already exists").(*errcodes.Error)
+	userExistsDup := errcodes.Rehydrate(errcodes.Exists, "user_exists", "The user account already exists").(*errcodes.Error)
+	userExistsConflict := errcodes.Rehydrate(errcodes.Conflict, "user_exists", "The user is taken").(*errcodes.Error)
+	userNotFound := errcodes.Rehydrate(errcodes.NotFound, "user_not_found", "The user does not exist").(*errcodes.Error)
+
+	tests := make(map[string]bool)
+	tests["register new code"] = r.Register(userExists) == nil
+	tests["register same declaration"] = r.Register(userExistsDup) == nil
+	tests["register conflicting declaration"] = errors.Is(r.Register(userExistsConflict), errcodes.ErrCodeConflict)
+	tests["register another code"] = r.Register(userNotFound) == nil
+
+	got, ok := r.Lookup("user_exists")
+	tests["lookup registered code"] = ok && got == userExists
+	_, ok = r.Lookup("unknown_code")
+	tests["lookup unknown code"] = !ok
+
+	all := r.All()
+	tests["all sorted by code"] = len(all) == 2 && all[0] == userExists && all[1] == userNotFound
+
+	r.Freeze()
+	tests["frozen"] = r.Frozen()
+	tests["register after freeze"] = errors.Is(r.Register(errcodes.Rehydrate(errcodes.Internal, "late", "Too late").(*errcodes.Error)), errcodes.ErrRegistryFrozen)
+
+	got, ok = errcodes.Lookup("user_exists")
+	tests["default registry lookup"] = ok && errors.Is(got, ErrUserExists)
+
+	for name, ok := range tests {
+		name, ok := name, ok
+		t.Run(name, func(t *testing.T) {
+			if !ok {
+				t.Fatal("want true, got false")
+			}
+		})
+	}
+}
+
+func TestNewConflictPanics(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, errcodes.ErrCodeConflict) {
+			t.Fatalf("want %v, got %v", errcodes.ErrCodeConflict, err)
+		}
+	}()
+
+	_ = errcodes.New(errcodes.Conflict, "user_exists", "The user is taken")
+}
+
+func TestKinds(t *testing.T) {
+	want := []errcodes.Kind{
+		errcodes.Aborted,
+		errcodes.BadRequest,
+		errcodes.Canceled,
+		errcodes.Conflict,
+		errcodes.DataLoss,
+		errcodes.DeadlineExceeded,
+		errcodes.Exists,
+		errcodes.Forbidden,
+		errcodes.Internal,
+		errcodes.NotFound,
+		errcodes.NotImplemented,
+		errcodes.OutOfRange,
+		errcodes.PreconditionFailed,
+		errcodes.TooManyRequests,
+		errcodes.Unauthorized,
+		errcodes.Unavailable,
+		errcodes.Unknown,
+
+		// Custom kinds registered by the tests.
+		PaymentRequired,
+		InsufficientStorage,
+	}
+	slices.Sort(want)
+
+	kinds := errcodes.Kinds()
+	if !slices.Equal(want, kinds) {
+		t.Fatalf("want kinds %v, got %v", want, kinds)
+	}
+
+	for _, kind := range kinds {
+		kind := kind
+		t.Run(string(kind), func(t *testing.T) {
+			if !kind.Valid() {
+				t.Fatal("want valid kind")
+			}
+			if kind.Description() == "" {
+				t.Fatal("want description")
+			}
+
+			status := errcodes.HTTPStatusCode(kind)
+			if want, got := status < 500, kind.IsClientFault(); want != got {
+				t.Fatalf("http status %d: want client fault %t, got %t", status, want, got)
+			}
+			if want, got := !kind.IsClientFault(), kind.IsServerFault(); want != got {
+				t.Fatalf("want server fault %t, got %t", want, got)
+			}
+		})
+	}
+}
+
+func TestRegisterKindInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		info errcodes.KindInfo
+	}{
+		{"http status below 400", errcodes.KindInfo{HTTPStatus: http.StatusOK, GRPCCode: codes.Internal}},
+		{"http status above 599", errcodes.KindInfo{HTTPStatus: 600, GRPCCode: codes.Internal}},
+		{"grpc code ok", errcodes.KindInfo{HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.OK}},
+		{"grpc code out of range", errcodes.KindInfo{HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.Unauthenticated + 1}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kind := errcodes.Kind("invalid_" + strings.ReplaceAll(tt.name, " ", "_"))
+			if err := errcodes.RegisterKind(kind, tt.info); err == nil {
+				t.Fatal("want error")
+			}
+			if kind.Valid() {
+				t.Fatal("want kind not registered")
+			}
+		})
+	}
+}
+
+var InsufficientStorage = errcodes.MustRegisterKind("insufficient_storage", errcodes.KindInfo{
+	HTTPStatus:  http.StatusInsufficientStorage,
+	GRPCCode:    codes.ResourceExhausted,
+	Description: "The server is unable to store the representation",
+	Severity:    errcodes.SeverityCritical,
+})
+
+var ErrStorageFull = errcodes.New(InsufficientStorage, "storage_full", "The storage is full")
+
+func TestJoinCustomKind(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []error
+		kind errcodes.Kind
+	}{
+		{"custom server fault outranks client fault", []error{errcodes.ErrValidationFailed, ErrStorageFull}, InsufficientStorage},
+		{"built-in server fault outranks custom server fault", []error{ErrStorageFull, ErrDatabase}, errcodes.Internal},
+		{"built-in client fault outranks custom client fault", []error{ErrSubscriptionExpired, errcodes.ErrValidationFailed}, errcodes.BadRequest},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var je *errcodes.JoinError
+			if !errors.As(errcodes.Join(tt.errs...), &je) {
+				t.Fatal("want JoinError")
+			}
+			if want, got := tt.kind, je.Kind(); want != got {
+				t.Fatalf("want kind %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestKindHelpers(t *testing.T) {
+	tests := make(map[string]bool)
+	tests["retryable"] = errcodes.Retryable(fmt.Errorf("call: %w", ErrServiceUnavailable))
+	tests["not retryable"] = !errcodes.Retryable(ErrUserExists)
+	tests["nil not retryable"] = !errcodes.Retryable(nil)
+	tests["client fault"] = errcodes.ClientFault(ErrUserExists)
+	tests["server fault for unknown error"] = errcodes.ServerFault(errors.New("boom"))
+	tests["severity"] = errcodes.SeverityOf(ErrUserExists) == errcodes.SeverityInfo
+	tests["severity for unknown error"] = errcodes.SeverityOf(errors.New("boom")) == errcodes.SeverityError
+	tests["invalid kind is server fault"] = errcodes.Kind("invalid").IsServerFault()
+
+	for name, ok := range tests {
+		name, ok := name, ok
+		t.Run(name, func(t *testing.T) {
+			if !ok {
+				t.Fatal("want true, got false")
+			}
+		})
+	}
+}
+
+func TestReverseMapping(t *testing.T) {
+	t.Run("tie-breaking", func(t *testing.T) {
+		grpcTests := []struct {
+			code codes.Code
+			kind errcodes.Kind
+		}{
+			{codes.Aborted, errcodes.Aborted},
+			{codes.FailedPrecondition, errcodes.PreconditionFailed},
+			{codes.InvalidArgument, errcodes.BadRequest},
+			{codes.OK, errcodes.Unknown},
+			{codes.Code(999), errcodes.Unknown},
+		}
+		for _, tt := range grpcTests {
+			if want, got := tt.kind, errcodes.KindFromGRPCCode(tt.code); want != got {
+				t.Errorf("KindFromGRPCCode(%s): want %s, got %s", tt.code, want, got)
+			}
+		}
+
+		httpTests := []struct {
+			status int
+			kind   errcodes.Kind
+			code   codes.Code
+		}{
+			{http.StatusBadRequest, errcodes.BadRequest, codes.InvalidArgument},
+			{http.StatusConflict, errcodes.Conflict, codes.Aborted},
+			{http.StatusInternalServerError, errcodes.Internal, codes.Internal},
+			{http.StatusPaymentRequired, PaymentRequired, codes.FailedPrecondition},
+			{http.StatusRequestTimeout, errcodes.DeadlineExceeded, codes.DeadlineExceeded},
+			{http.StatusPreconditionFailed, errcodes.PreconditionFailed, codes.FailedPrecondition},
+			{http.StatusBadGateway, errcodes.Unavailable, codes.Unavailable},
+
+			// Fallback by status class.
+			{http.StatusMethodNotAllowed, errcodes.BadRequest, codes.InvalidArgument},
+			{http.StatusRequestEntityTooLarge, errcodes.BadRequest, codes.InvalidArgument},
+			{http.StatusTeapot, errcodes.BadRequest, codes.InvalidArgument},
+			{http.StatusUnprocessableEntity, errcodes.BadRequest, codes.InvalidArgument},
+			{http.StatusHTTPVersionNotSupported, errcodes.Internal, codes.Internal},
+			{http.StatusOK, errcodes.Unknown, codes.OK},
+			{http.StatusNoContent, errcodes.Unknown, codes.OK},
+			{http.StatusFound, errcodes.Unknown, codes.Unknown},
+		}
+		for _, tt := range httpTests {
+			if want, got := tt.kind, errcodes.KindFromHTTPStatus(tt.status); want != got {
+				t.Errorf("KindFromHTTPStatus(%d): want %s, got %s", tt.status, want, got)
+			}
+			if want, got := tt.code, errcodes.HTTPStatusToGRPCCode(tt.status); want != got {
+				t.Errorf("HTTPStatusToGRPCCode(%d): want %s, got %s", tt.status, want, got)
+			}
+		}
+	})
+
+	t.Run("round-trip", func(t *testing.T) {
+		// Repeat to catch any dependency on map iteration order.
+		for i := 0; i < 100; i++ {
+			for _, kind := range errcodes.Kinds() {
+				code := errcodes.GRPCCode(kind)
+				if want, got := code, errcodes.GRPCCode(errcodes.KindFromGRPCCode(code)); want != got {
+					t.Fatalf("%s: want gRPC code %s, got %s", kind, want, got)
+				}
+
+				status := errcodes.HTTPStatusCode(kind)
+				if want, got := status, errcodes.HTTPStatusCode(errcodes.KindFromHTTPStatus(status)); want != got {
+					t.Fatalf("%s: want HTTP status %d, got %d", kind, want, got)
+				}
+
+				if want, got := status, errcodes.GRPCCodeToHTTP(code); kind == errcodes.KindFromGRPCCode(code) && want != got {
+					t.Fatalf("%s: want HTTP status %d, got %d", kind, want, got)
+				}
+			}
+
+			if want, got := http.StatusConflict, errcodes.GRPCCodeToHTTP(codes.Aborted); want != got {
+				t.Fatalf("want HTTP status %d, got %d", want, got)
+			}
+		}
+	})
+}
+
+func TestLogValue(t *testing.T) {
+	err := stacktrace.Wrap(ErrUserExists, "create user")
+
+	attrs := make(map[string]slog.Value)
+	for _, a := range errcodes.LogValue(err).Group() {
+		attrs[a.Key] = a.Value
+	}
+
+	if got, want := attrs["kind"].String(), string(errcodes.Exists); got != want {
+		t.Fatalf("kind: want %q, got %q", want, got)
+	}
+
+	if got, want := attrs["http_status"].Int64(), int64(http.StatusConflict); got != want {
+		t.Fatalf("http_status: want %d, got %d", want, got)
+	}
+
+	frames, ok := attrs["frames"].Any().([]stacktrace.Frame)
+	if !ok || len(frames) == 0 {
+		t.Fatalf("frames: want stack trace, got %v", attrs["frames"])
+	}
+
+	// The stack trace alone logs the message and the frames.
+	var lv slog.LogValuer = err.(*stacktrace.ErrorTrace)
+	if got, want := len(lv.LogValue().Group()), 2; got != want {
+		t.Fatalf("stack trace attrs: want %d, got %d", want, got)
+	}
+}
+
+func TestWithInternalWrapped(t *testing.T) {
+	cause := errors.New("db: duplicate key")
+	err := stacktrace.Wrap(ErrUserExists, "create user")
+	err = errcodes.WithInternal(err, "email %q is taken", "john.doe@mail.com")
+	err = errcodes.WithCause(err, cause)
+
+	var ec *errcodes.Error
+	if !errors.As(err, &ec) {
+		t.Fatal("want domain error")
+	}
+	if got, want := ec.Internal(), `email "john.doe@mail.com" is taken`; got != want {
+		t.Fatalf("internal: want %q, got %q", want, got)
+	}
+
+	if !errors.Is(err, ErrUserExists) {
+		t.Fatal("want errors.Is sentinel")
+	}
+	if !errors.Is(err, cause) {
+		t.Fatal("want errors.Is cause")
+	}
+
+	// The outer error chain is kept.
+	if got := stacktrace.StackTrace(err); len(got) == 0 {
+		t.Fatal("want stack trace")
+	}
+}
+
+func TestWithDetailsWrapped(t *testing.T) {
+	err := stacktrace.Wrap(ErrUserExists, "create user")
+	err = errcodes.WithDetails(err, errcodes.RetryInfo{RetryDelay: time.Second})
+
+	var ec *errcodes.Error
+	if !errors.As(err, &ec) {
+		t.Fatal("want domain error")
+	}
+	if got, want := len(ec.Details()), 1; got != want {
+		t.Fatalf("details: want %d, got %d", want, got)
+	}
+
+	// The outer error chain is kept.
+	if got := stacktrace.StackTrace(err); len(got) == 0 {
+		t.Fatal("want stack trace")
+	}
+}
+
+func TestFormatStackTrace(t *testing.T) {
+	err := errcodes.FromError(stacktrace.New("connection refused"))
+
+	got := fmt.Sprintf("%+v", err)
+	for _, want := range []string{
+		"unknown/: An unknown error occurred\nCaused by: connection refused\n",
+		"Error: An unknown error occurred: connection refused\n",
+		"TestFormatStackTrace",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("want %q in:\n%s", want, got)
+		}
+	}
+
+	if got, want := fmt.Sprintf("%s", err), err.Error(); got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,7 @@
+// Package errcodespb contains the protobuf schema of errcodes.Error, for
+// carrying errors outside of a gRPC status, e.g. in events or job results.
+//
+// Use errcodes.ToProto and errcodes.FromProto to convert between the two.
+package errcodespb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative ../errcodespb/errcodes.proto
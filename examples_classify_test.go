@@ -0,0 +1,67 @@
+package errcodes_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+type PaymentDeclinedError struct {
+	Reason string
+}
+
+func (e *PaymentDeclinedError) Error() string {
+	return "payment declined: " + e.Reason
+}
+
+func ExampleClassify() {
+	errcodes.RegisterClassifier(func(err error) (errcodes.Kind, bool) {
+		var pe *PaymentDeclinedError
+		if errors.As(err, &pe) {
+			return errcodes.PreconditionFailed, true
+		}
+
+		return "", false
+	})
+
+	_, err := os.Open("/path/does/not/exist")
+	fmt.Println("1.", errcodes.Classify(err))
+	fmt.Println("2.", errcodes.Classify(fmt.Errorf("find user: %w", sql.ErrNoRows)))
+	fmt.Println("3.", errcodes.Classify(context.Canceled))
+	fmt.Println("4.", errcodes.Classify(fs.ErrPermission))
+	fmt.Println("5.", errcodes.Classify(&PaymentDeclinedError{Reason: "insufficient funds"}))
+	fmt.Println("6.", errcodes.Classify(ErrUserExists))
+	fmt.Println("7.", errcodes.Classify(errors.New("boom")))
+
+	// Output:
+	// 1. not_found
+	// 2. not_found
+	// 3. cancelled
+	// 4. forbidden
+	// 5. precondition_failed
+	// 6. exists
+	// 7. unknown
+}
+
+func ExampleFromError() {
+	err := errcodes.FromError(fmt.Errorf("query: %w", context.DeadlineExceeded))
+
+	var ec *errcodes.Error
+	if errors.As(err, &ec) {
+		fmt.Println("1.", ec.Kind())
+		fmt.Println("2.", ec.Message())
+	}
+	fmt.Println("3.", err)
+	fmt.Println("4.", errors.Is(err, context.DeadlineExceeded))
+
+	// Output:
+	// 1. deadline_exceeded
+	// 2. The deadline expired before the operation could complete
+	// 3. The deadline expired before the operation could complete: query: context deadline exceeded
+	// 4. true
+}
@@ -0,0 +1,76 @@
+package errcodes_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alextanhongpin/errcodes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrRateLimited = errcodes.New(errcodes.TooManyRequests, "rate_limited", "Too many requests, please try again later")
+
+func ExampleWithDetails() {
+	err := errcodes.WithDetails(ErrRateLimited,
+		errcodes.RetryInfo{RetryDelay: 1500 * time.Millisecond},
+		errcodes.QuotaFailure{
+			Violations: []errcodes.QuotaViolation{
+				{Subject: "user:42", Description: "Daily limit exceeded"},
+			},
+		},
+		errcodes.Help{
+			Links: []errcodes.Link{
+				{Description: "Rate limits", URL: "https://example.com/docs/rate-limits"},
+			},
+		},
+	)
+	fmt.Println("1.", errors.Is(err, ErrRateLimited))
+
+	var ec *errcodes.Error
+	errors.As(err, &ec)
+	b, err := json.MarshalIndent(ec.Details(), "", " ")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("2.", string(b))
+
+	// The details are carried over gRPC.
+	err = errcodes.FromGRPCStatus(status.Convert(ec))
+	errors.As(err, &ec)
+	fmt.Printf("3. %+v\n", ec.Details())
+
+	// The sentinel is not mutated.
+	errors.As(ErrRateLimited, &ec)
+	fmt.Println("4.", len(ec.Details()))
+
+	// Output:
+	// 1. true
+	// 2. [
+	//  {
+	//   "@type": "type.googleapis.com/google.rpc.RetryInfo",
+	//   "retryDelay": "1.500s"
+	//  },
+	//  {
+	//   "@type": "type.googleapis.com/google.rpc.QuotaFailure",
+	//   "violations": [
+	//    {
+	//     "subject": "user:42",
+	//     "description": "Daily limit exceeded"
+	//    }
+	//   ]
+	//  },
+	//  {
+	//   "@type": "type.googleapis.com/google.rpc.Help",
+	//   "links": [
+	//    {
+	//     "description": "Rate limits",
+	//     "url": "https://example.com/docs/rate-limits"
+	//    }
+	//   ]
+	//  }
+	// ]
+	// 3. [{RetryDelay:1.5s} {Violations:[{Subject:user:42 Description:Daily limit exceeded}]} {Links:[{Description:Rate limits URL:https://example.com/docs/rate-limits}]}]
+	// 4. 0
+}
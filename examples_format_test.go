@@ -0,0 +1,23 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+func ExampleError_Format() {
+	err := errcodes.FromError(fmt.Errorf("create user: %w", errors.New("connection refused")))
+
+	fmt.Printf("1. %v\n", err)
+	fmt.Printf("2. %q\n", err)
+	fmt.Printf("3. %+v\n", err)
+
+	// Output:
+	// 1. An unknown error occurred: create user: connection refused
+	// 2. "An unknown error occurred"
+	// 3. unknown/: An unknown error occurred
+	// Caused by: create user: connection refused
+	// Caused by: connection refused
+}
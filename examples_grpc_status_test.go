@@ -0,0 +1,44 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func ExampleFromGRPCStatus() {
+	st, ok := status.FromError(ErrUserExists)
+	fmt.Println("1.", ok)
+	fmt.Println("2.", st.Code())
+	fmt.Println("3.", st.Message())
+
+	// Simulate sending the status over the wire.
+	b, err := proto.Marshal(st.Proto())
+	if err != nil {
+		panic(err)
+	}
+
+	var pb spb.Status
+	if err := proto.Unmarshal(b, &pb); err != nil {
+		panic(err)
+	}
+
+	err = errcodes.FromGRPCStatus(status.FromProto(&pb))
+	fmt.Println("4.", errors.Is(err, ErrUserExists))
+
+	var ec *errcodes.Error
+	if errors.As(err, &ec) {
+		fmt.Println("5.", ec.String())
+	}
+
+	// Output:
+	// 1. true
+	// 2. AlreadyExists
+	// 3. The user account already exists
+	// 4. true
+	// 5. exists/user_exists: The user account already exists
+}
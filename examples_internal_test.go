@@ -0,0 +1,33 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+	"google.golang.org/grpc/status"
+)
+
+func ExampleWithInternal() {
+	err := errcodes.WithInternal(ErrUserExists, "email %q is taken", "john.doe@mail.com")
+	err = errcodes.WithCause(err, errors.New("db: duplicate key"))
+
+	var ec *errcodes.Error
+	errors.As(err, &ec)
+	fmt.Println("1.", ec.Message())
+	fmt.Println("2.", ec.Internal())
+	fmt.Println("3.", err)
+	fmt.Println("4.", ec.String())
+	fmt.Println("5.", errors.Is(err, ErrUserExists))
+
+	// Only the public message is exposed over gRPC.
+	fmt.Println("6.", status.Convert(ec).Message())
+
+	// Output:
+	// 1. The user account already exists
+	// 2. email "john.doe@mail.com" is taken
+	// 3. The user account already exists: email "john.doe@mail.com" is taken: db: duplicate key
+	// 4. exists/user_exists: The user account already exists: email "john.doe@mail.com" is taken
+	// 5. true
+	// 6. The user account already exists
+}
@@ -0,0 +1,59 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+var ErrDatabase = errcodes.New(errcodes.Internal, "database_error", "The database is unavailable")
+
+func ExampleJoin() {
+	err := errcodes.Join(
+		ErrDuplicateEmail,
+		fmt.Errorf("save profile: %w", ErrDatabase),
+		nil,
+	)
+
+	var je *errcodes.JoinError
+	if errors.As(err, &je) {
+		fmt.Println("1.", je.Kind())
+		fmt.Println("2.", errcodes.HTTPStatusCode(je.Kind()))
+		fmt.Println("3.", errcodes.GRPCCode(je.Kind()))
+
+		for _, ec := range je.Errors() {
+			fmt.Println("4.", ec.String())
+		}
+	}
+
+	// errors.As finds the domain error with the effective kind.
+	var ec *errcodes.Error
+	if errors.As(err, &ec) {
+		fmt.Println("5.", ec.Code())
+	}
+	fmt.Println("6.", errors.Is(err, ErrDuplicateEmail))
+
+	// All client faults stays 4xx.
+	err = errcodes.Join(ErrDuplicateEmail, errcodes.ErrValidationFailed)
+	errors.As(err, &je)
+	fmt.Println("7.", je.Kind(), errcodes.HTTPStatusCode(je.Kind()))
+
+	// An unrecognized error is not hidden behind a client fault.
+	err = errcodes.Join(errcodes.ErrValidationFailed, errors.New("db down"))
+	errors.As(err, &je)
+	fmt.Println("8.", je.Kind(), errcodes.HTTPStatusCode(je.Kind()))
+	fmt.Println("9.", len(je.Errors()))
+
+	// Output:
+	// 1. internal
+	// 2. 500
+	// 3. Internal
+	// 4. conflict/email_duplicate: The email address is not available
+	// 4. internal/database_error: The database is unavailable
+	// 5. database_error
+	// 6. true
+	// 7. conflict 409
+	// 8. internal 500
+	// 9. 1
+}
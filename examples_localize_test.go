@@ -0,0 +1,37 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+	"golang.org/x/text/language"
+)
+
+func ExampleLocalize() {
+	c := errcodes.NewCatalog()
+	c.Set(language.Malay, "account_exists", "Akaun pengguna sudah wujud")
+
+	err := c.Localize(fmt.Errorf("create account: %w", ErrAccountExists), language.Malay)
+	fmt.Println("1.", err)
+	fmt.Println("2.", errors.Is(err, ErrAccountExists))
+
+	// Falls back to the original message.
+	err = c.Localize(ErrAccountExists, language.Japanese)
+	fmt.Println("3.", err)
+
+	tag, ok := c.MatchAcceptLanguage("ja, ms-MY;q=0.8, en;q=0.5")
+	fmt.Println("4.", tag, ok)
+
+	r := errcodes.NewRegistry()
+	r.MustRegister(ErrAccountExists.(*errcodes.Error))
+	r.MustRegister(ErrDuplicateEmail.(*errcodes.Error))
+	fmt.Println("5.", c.Validate(r))
+
+	// Output:
+	// 1. Akaun pengguna sudah wujud
+	// 2. true
+	// 3. The user account already exists
+	// 4. ms true
+	// 5. errcodes: missing translation: "email_duplicate" in ms
+}
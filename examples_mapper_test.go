@@ -0,0 +1,35 @@
+package errcodes_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/alextanhongpin/errcodes"
+	"google.golang.org/grpc/codes"
+)
+
+var ErrOrderNotPending = errcodes.New(errcodes.PreconditionFailed, "order_not_pending", "The order is not pending")
+
+func ExampleMapper() {
+	m := errcodes.NewMapper().
+		SetKindHTTPStatus(errcodes.PreconditionFailed, http.StatusPreconditionFailed).
+		SetCodeHTTPStatus("account_exists", http.StatusUnprocessableEntity).
+		SetCodeGRPCCode("account_exists", codes.FailedPrecondition)
+
+	fmt.Println("1.", m.HTTPStatusCode(ErrOrderNotPending))
+	fmt.Println("2.", m.HTTPStatusCode(ErrAccountExists))
+	fmt.Println("3.", m.GRPCCode(ErrAccountExists))
+	fmt.Println("4.", m.GRPCStatus(ErrAccountExists).Code())
+
+	// Unaffected errors use the defaults.
+	fmt.Println("5.", m.HTTPStatusCode(ErrDuplicateEmail))
+	fmt.Println("6.", errcodes.DefaultMapper.HTTPStatusCode(ErrOrderNotPending))
+
+	// Output:
+	// 1. 412
+	// 2. 422
+	// 3. FailedPrecondition
+	// 4. FailedPrecondition
+	// 5. 409
+	// 6. 400
+}
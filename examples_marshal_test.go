@@ -0,0 +1,49 @@
+package errcodes_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+func ExampleError_MarshalJSON() {
+	b, err := json.Marshal(ErrDuplicateEmail)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("1.", string(b))
+
+	var ec *errcodes.Error
+	if err := json.Unmarshal(b, &ec); err != nil {
+		panic(err)
+	}
+	fmt.Println("2.", errors.Is(ec, ErrDuplicateEmail))
+
+	// Custom kinds that are not registered become unknown.
+	if err := json.Unmarshal([]byte(`{"kind":"payment_declined","code":"card_declined","message":"The card was declined"}`), &ec); err != nil {
+		panic(err)
+	}
+	fmt.Println("3.", ec.Kind(), ec.Code())
+
+	var kind errcodes.Kind
+	fmt.Println("4.", kind.UnmarshalText([]byte("canceled")), kind)
+	fmt.Println("5.", kind.UnmarshalText([]byte("cancelled")), kind)
+	fmt.Println("6.", kind.UnmarshalText([]byte("invalid")))
+	fmt.Println("7.", kind.Scan("invalid"))
+
+	// Invalid kinds are only rejected when decoding.
+	b, err = json.Marshal(struct{ Kind errcodes.Kind }{})
+	fmt.Println("8.", string(b), err)
+
+	// Output:
+	// 1. {"kind":"conflict","code":"email_duplicate","message":"The email address is not available"}
+	// 2. true
+	// 3. unknown card_declined
+	// 4. <nil> cancelled
+	// 5. <nil> cancelled
+	// 6. errcodes: invalid kind: "invalid"
+	// 7. errcodes: invalid kind: "invalid"
+	// 8. {"Kind":""} <nil>
+}
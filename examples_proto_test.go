@@ -0,0 +1,59 @@
+package errcodes_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alextanhongpin/errcodes"
+	"github.com/alextanhongpin/errcodes/errcodespb"
+	"google.golang.org/protobuf/proto"
+)
+
+func ExampleToProto() {
+	err := errcodes.WithDetails(ErrRateLimited, errcodes.RetryInfo{RetryDelay: time.Second})
+
+	b, err := proto.Marshal(errcodes.ToProto(err))
+	if err != nil {
+		panic(err)
+	}
+
+	var pb errcodespb.Error
+	if err := proto.Unmarshal(b, &pb); err != nil {
+		panic(err)
+	}
+
+	err = errcodes.FromProto(&pb)
+	fmt.Println("1.", err)
+	fmt.Println("2.", errors.Is(err, ErrRateLimited))
+
+	var ec *errcodes.Error
+	errors.As(err, &ec)
+	fmt.Printf("3. %+v\n", ec.Details())
+
+	// The cause chain is preserved.
+	err = errcodes.FromProto(errcodes.ToProto(errcodes.FromError(context.DeadlineExceeded)))
+	fmt.Println("4.", err)
+	fmt.Println("5.", errors.Unwrap(err))
+
+	// The codes of the field violations are preserved.
+	var v errcodes.Validation
+	v.Add("email", "email_invalid", "The email is invalid")
+	b, err = proto.Marshal(errcodes.ToProto(v.Err()))
+	if err != nil {
+		panic(err)
+	}
+	if err := proto.Unmarshal(b, &pb); err != nil {
+		panic(err)
+	}
+	fmt.Printf("6. %+v\n", errcodes.Violations(errcodes.FromProto(&pb)))
+
+	// Output:
+	// 1. Too many requests, please try again later
+	// 2. true
+	// 3. [{RetryDelay:1s}]
+	// 4. The deadline expired before the operation could complete: context deadline exceeded
+	// 5. context deadline exceeded
+	// 6. [{Field:email Code:email_invalid Description:The email is invalid}]
+}
@@ -0,0 +1,45 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/alextanhongpin/errcodes"
+	"google.golang.org/grpc/codes"
+)
+
+var PaymentRequired = errcodes.MustRegisterKind("payment_required", errcodes.KindInfo{
+	HTTPStatus:  http.StatusPaymentRequired,
+	GRPCCode:    codes.FailedPrecondition,
+	Description: "Payment is required to continue",
+})
+
+var ErrSubscriptionExpired = errcodes.New(PaymentRequired, "subscription_expired", "The subscription has expired")
+
+func ExampleRegisterKind() {
+	var ec *errcodes.Error
+	errors.As(ErrSubscriptionExpired, &ec)
+
+	fmt.Println("1.", ec.Kind().Valid())
+	fmt.Println("2.", errcodes.HTTPStatusCode(ec.Kind()))
+	fmt.Println("3.", errcodes.GRPCCode(ec.Kind()))
+	fmt.Println("4.", ec.Kind().IsClientFault())
+
+	// Built-in kinds cannot be clobbered.
+	err := errcodes.RegisterKind(errcodes.NotFound, errcodes.KindInfo{HTTPStatus: http.StatusGone})
+	fmt.Println("5.", errors.Is(err, errcodes.ErrKindExists))
+	fmt.Println("6.", errcodes.HTTPStatusCode(errcodes.NotFound))
+
+	// The gRPC code maps back to the built-in kind, not the custom kind.
+	fmt.Println("7.", errcodes.GRPCCodeToHTTP(codes.FailedPrecondition))
+
+	// Output:
+	// 1. true
+	// 2. 402
+	// 3. FailedPrecondition
+	// 4. true
+	// 5. true
+	// 6. 404
+	// 7. 400
+}
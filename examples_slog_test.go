@@ -0,0 +1,33 @@
+package errcodes_test
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+func ExampleNewLogHandler() {
+	h := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	})
+	logger := slog.New(errcodes.NewLogHandler(h))
+	logger.Error("1.", "err", ErrUserExists)
+	logger.Error("2.", "err", fmt.Errorf("create user: %w", ErrUserExists))
+	logger.Error("3.", "err", errcodes.WithDetails(
+		AccountExists.New(AccountExistsParams{ID: "user-42"}),
+		errcodes.ResourceInfo{ResourceType: "account", ResourceName: "user-42"},
+	))
+
+	// Output:
+	// {"level":"ERROR","msg":"1.","err":{"kind":"exists","code":"user_exists","message":"The user account already exists","http_status":409}}
+	// {"level":"ERROR","msg":"2.","err":{"kind":"exists","code":"user_exists","message":"The user account already exists","http_status":409,"error":"create user: The user account already exists"}}
+	// {"level":"ERROR","msg":"3.","err":{"kind":"exists","code":"account_exists","message":"The user account user-42 already exists","http_status":409,"params":{"ID":"user-42"},"details":[{"@type":"type.googleapis.com/google.rpc.ResourceInfo","resourceType":"account","resourceName":"user-42"}]}}
+}
@@ -0,0 +1,61 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+	"golang.org/x/text/language"
+)
+
+type AccountExistsParams struct {
+	ID string
+}
+
+var AccountExists = errcodes.NewTemplate[AccountExistsParams](ErrAccountExists, "The user account {{.ID}} already exists")
+
+func ExampleTemplate() {
+	err := AccountExists.New(AccountExistsParams{ID: "user-42"})
+	fmt.Println("1.", err)
+	fmt.Println("2.", errors.Is(err, ErrAccountExists))
+
+	params, ok := AccountExists.Params(fmt.Errorf("create account: %w", err))
+	fmt.Println("3.", params.ID, ok)
+
+	var ec *errcodes.Error
+	if errors.As(err, &ec) {
+		fmt.Println("4.", ec.String())
+		fmt.Printf("5. %+v\n", ec.Params())
+	}
+
+	// The sentinel is not mutated.
+	fmt.Println("6.", ErrAccountExists)
+
+	// Output:
+	// 1. The user account user-42 already exists
+	// 2. true
+	// 3. user-42 true
+	// 4. exists/account_exists: The user account user-42 already exists
+	// 5. {ID:user-42}
+	// 6. The user account already exists
+}
+
+func ExampleTemplate_localize() {
+	c := errcodes.NewCatalog()
+	c.Set(language.Malay, "account_exists", "Akaun pengguna {{.ID}} sudah wujud")
+
+	err := AccountExists.New(AccountExistsParams{ID: "user-42"})
+	fmt.Println("1.", c.Localize(err, language.Malay))
+
+	params, ok := AccountExists.Params(c.Localize(err, language.Malay))
+	fmt.Println("2.", params.ID, ok)
+
+	// Translations that cannot be rendered fall back to the original.
+	c.Set(language.Malay, "account_exists", "Akaun pengguna {{.Name}} sudah wujud")
+	fmt.Println("3.", c.Localize(err, language.Malay))
+
+	// Output:
+	// 1. Akaun pengguna user-42 sudah wujud
+	// 2. user-42 true
+	// 3. The user account user-42 already exists
+}
@@ -0,0 +1,59 @@
+package errcodes_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+type CreateUserRequest struct {
+	Name  string
+	Email string
+}
+
+func (r *CreateUserRequest) Validate() error {
+	var v errcodes.Validation
+	if r.Name == "" {
+		v.Add("name", "required", "The name is required")
+	}
+	if r.Email == "" {
+		v.Add("email", "required", "The email is required")
+	}
+
+	return v.Err()
+}
+
+func ExampleValidation() {
+	req := &CreateUserRequest{}
+	err := req.Validate()
+	fmt.Println("1.", errors.Is(err, errcodes.ErrValidationFailed))
+
+	for _, v := range errcodes.Violations(err) {
+		fmt.Println("2.", v.Field, v.Code, v.Description)
+	}
+
+	st := status.Convert(err)
+	fmt.Println("3.", st.Code())
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, fv := range br.GetFieldViolations() {
+				fmt.Println("4.", fv.GetField(), fv.GetDescription())
+			}
+		}
+	}
+
+	req = &CreateUserRequest{Name: "John", Email: "john.doe@mail.com"}
+	fmt.Println("5.", req.Validate())
+
+	// Output:
+	// 1. true
+	// 2. name required The name is required
+	// 2. email required The email is required
+	// 3. InvalidArgument
+	// 4. name The name is required
+	// 4. email The email is required
+	// 5. <nil>
+}
@@ -0,0 +1,31 @@
+package errcodes
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alextanhongpin/errcodes/stacktrace"
+)
+
+// Format satisfies the fmt.Formatter interface.
+// The %+v verb prints the kind and code, the wrap chain of the cause, and
+// the stack trace if there is any. The %q verb quotes the public message,
+// and other verbs format the error string.
+func (e *Error) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		io.WriteString(s, stacktrace.Chain(e))
+		if len(stacktrace.StackTrace(e)) > 0 {
+			io.WriteString(s, "\n")
+			io.WriteString(s, stacktrace.Sprint(e, false))
+		}
+
+		return
+	}
+
+	if verb == 'q' {
+		fmt.Fprintf(s, fmt.FormatString(s, verb), e.message)
+		return
+	}
+
+	fmt.Fprintf(s, fmt.FormatString(s, verb), e.Error())
+}
@@ -0,0 +1,90 @@
+package errcodes
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// grpcDomain is the domain of the google.rpc.ErrorInfo detail that carries
+// the kind and code of an Error over the wire.
+const grpcDomain = "errcodes"
+
+const grpcKindKey = "kind"
+
+// GRPCStatus returns the gRPC status for the error, allowing status.FromError
+// to work directly. The kind and code are carried in a google.rpc.ErrorInfo
+// detail, followed by the structured details of the error.
+// The codes of the field violations are carried in the ErrorInfo metadata.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.grpcStatus(GRPCCode(e.kind))
+}
+
+func (e *Error) grpcStatus(code codes.Code) *status.Status {
+	metadata := e.details.fieldViolationCodes()
+	metadata[grpcKindKey] = string(e.kind)
+
+	msgs := []proto.Message{
+		&errdetails.ErrorInfo{
+			Reason:   string(e.code),
+			Domain:   grpcDomain,
+			Metadata: metadata,
+		},
+	}
+	for _, d := range e.details {
+		msgs = append(msgs, d.proto())
+	}
+
+	pb := &spb.Status{
+		Code:    int32(code),
+		Message: e.message,
+	}
+	for _, m := range msgs {
+		a, err := anypb.New(m)
+		if err != nil {
+			return status.New(code, e.message)
+		}
+		pb.Details = append(pb.Details, a)
+	}
+
+	return status.FromProto(pb)
+}
+
+// FromGRPCStatus returns the error for the given gRPC status.
+// The kind and code are recovered from the google.rpc.ErrorInfo detail if
+// present, otherwise the kind is derived from the gRPC code.
+// It returns nil if the status is OK.
+func FromGRPCStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	kind := KindFromGRPCCode(st.Code())
+
+	var code Code
+	var details Details
+	var metadata map[string]string
+	var found bool
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == grpcDomain && !found {
+			if k := Kind(info.GetMetadata()[grpcKindKey]); k.Valid() {
+				kind = k
+			}
+			code = Code(info.GetReason())
+			metadata = info.GetMetadata()
+			found = true
+
+			continue
+		}
+
+		if detail, ok := detailFromProto(d); ok {
+			details = append(details, detail)
+		}
+	}
+	details.setFieldViolationCodes(metadata)
+
+	return WithDetails(Rehydrate(kind, code, st.Message()), details...)
+}
@@ -0,0 +1,163 @@
+// Package grpcerr translates errors at the gRPC boundary.
+package grpcerr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alextanhongpin/errcodes"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const internalMessage = "internal error"
+
+// UnaryServerInterceptor returns a server interceptor that translates the
+// error returned by the handler into a gRPC status.
+// The message is localized to the locale from the incoming metadata.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatus(o.mapper, localize(ctx, err))
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor that translates the
+// error returned by the stream handler into a gRPC status.
+// The message is localized to the locale from the incoming metadata.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatus(o.mapper, localize(ss.Context(), handler(srv, ss)))
+	}
+}
+
+// Locale returns the locale in the errcodes.DefaultCatalog that matches the
+// accept-language key of the incoming metadata.
+func Locale(ctx context.Context) (language.Tag, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return language.Und, false
+	}
+
+	for _, accept := range md.Get("accept-language") {
+		if tag, ok := errcodes.DefaultCatalog.MatchAcceptLanguage(accept); ok {
+			return tag, true
+		}
+	}
+
+	return language.Und, false
+}
+
+func localize(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	tag, ok := Locale(ctx)
+	if !ok {
+		return err
+	}
+
+	return errcodes.Localize(err, tag)
+}
+
+// ToStatus returns the gRPC status error for the domain error in the
+// error chain.
+// If there is no domain error, the error is classified with
+// errcodes.Classify, errors with a gRPC status keep their code, and
+// unrecognized errors default to internal error.
+// The original cause is never exposed.
+func ToStatus(err error) error {
+	return toStatus(errcodes.DefaultMapper, err)
+}
+
+func toStatus(m *errcodes.Mapper, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var ec *errcodes.Error
+	if errors.As(err, &ec) {
+		if ec.Message() == "" {
+			return status.Error(m.GRPCCode(err), internalMessage)
+		}
+
+		return m.GRPCStatus(err).Err()
+	}
+
+	if errcodes.Classify(err) != errcodes.Unknown {
+		return m.GRPCStatus(errcodes.FromError(err)).Err()
+	}
+
+	// Errors with a gRPC status, e.g. the unimplemented stubs, keep their
+	// code, but not their message.
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		if code := se.GRPCStatus().Code(); code != codes.OK {
+			return status.Error(code, internalMessage)
+		}
+	}
+
+	return status.Error(m.GRPCCode(err), internalMessage)
+}
+
+// UnaryClientInterceptor returns a client interceptor that rehydrates the
+// gRPC status returned by the server into a domain error.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromStatus(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor that rehydrates the
+// gRPC status returned by the server into a domain error, both when opening
+// the stream and when sending or receiving messages.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromStatus(err)
+		}
+
+		return &clientStream{ClientStream: cs}, nil
+	}
+}
+
+// FromStatus returns the domain error for the gRPC status error.
+// Errors that are not gRPC status errors, such as io.EOF at the end of a
+// stream, are returned as it is.
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return errcodes.FromGRPCStatus(st)
+}
+
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	return FromStatus(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	return FromStatus(s.ClientStream.RecvMsg(m))
+}
+
+func (s *clientStream) CloseSend() error {
+	return FromStatus(s.ClientStream.CloseSend())
+}
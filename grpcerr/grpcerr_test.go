@@ -0,0 +1,259 @@
+package grpcerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/alextanhongpin/errcodes"
+	"github.com/alextanhongpin/errcodes/grpcerr"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var ErrUserExists = errcodes.New(errcodes.Exists, "user_exists", "The user account already exists")
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	err error
+}
+
+func (s *healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, s.err
+}
+
+func (s *healthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	return s.err
+}
+
+func dial(t *testing.T, err error, opts ...grpc.DialOption) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	return dialWithMapper(t, err, errcodes.DefaultMapper, opts...)
+}
+
+func dialWithMapper(t *testing.T, err error, m *errcodes.Mapper, opts ...grpc.DialOption) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	return dialServer(t, &healthServer{err: err}, m, opts...)
+}
+
+func dialServer(t *testing.T, hs grpc_health_v1.HealthServer, m *errcodes.Mapper, opts ...grpc.DialOption) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcerr.UnaryServerInterceptor(grpcerr.WithMapper(m))),
+		grpc.StreamInterceptor(grpcerr.StreamServerInterceptor(grpcerr.WithMapper(m))),
+	)
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	opts = append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.Dial("bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"domain error", ErrUserExists, codes.AlreadyExists, "The user account already exists"},
+		{"wrapped domain error", fmt.Errorf("create user: %w", ErrUserExists), codes.AlreadyExists, "The user account already exists"},
+		{"internal message", errcodes.WithCause(errcodes.WithInternal(ErrUserExists, "email %q is taken", "john.doe@mail.com"), errors.New("db: duplicate key")), codes.AlreadyExists, "The user account already exists"},
+		{"classified error", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded, "The deadline expired before the operation could complete"},
+		{"unknown error", errors.New("db: connection refused"), codes.Internal, "internal error"},
+		{"mixed join", errcodes.Join(errcodes.ErrValidationFailed, errors.New("db: connection refused")), codes.Internal, "internal error"},
+		{"status error", fmt.Errorf("call: %w", status.Error(codes.Unavailable, "connection refused")), codes.Unavailable, "internal error"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t, tt.err)
+			ctx := context.Background()
+
+			_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			assertStatus(t, err, tt.code, tt.message)
+
+			stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = stream.Recv()
+			assertStatus(t, err, tt.code, tt.message)
+		})
+	}
+}
+
+func TestServerInterceptorLocalize(t *testing.T) {
+	errcodes.DefaultCatalog.Set(language.Malay, "user_exists", "Akaun pengguna sudah wujud")
+
+	client := dial(t, ErrUserExists)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "ms-MY, en;q=0.8")
+
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assertStatus(t, err, codes.AlreadyExists, "Akaun pengguna sudah wujud")
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	assertStatus(t, err, codes.AlreadyExists, "Akaun pengguna sudah wujud")
+}
+
+func TestServerInterceptorWithMapper(t *testing.T) {
+	m := errcodes.NewMapper().SetKindGRPCCode(errcodes.Exists, codes.FailedPrecondition)
+
+	client := dialWithMapper(t, ErrUserExists, m)
+	ctx := context.Background()
+
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assertStatus(t, err, codes.FailedPrecondition, "The user account already exists")
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	assertStatus(t, err, codes.FailedPrecondition, "The user account already exists")
+
+	// The fallback for unrecognized errors is also mapped.
+	m = errcodes.NewMapper().SetKindGRPCCode(errcodes.Internal, codes.Unavailable)
+
+	client = dialWithMapper(t, errors.New("db: connection refused"), m)
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assertStatus(t, err, codes.Unavailable, "internal error")
+}
+
+func TestServerInterceptorUnimplemented(t *testing.T) {
+	// The stubs return a status error, which keeps its code.
+	client := dialServer(t, &grpc_health_v1.UnimplementedHealthServer{}, errcodes.DefaultMapper)
+	ctx := context.Background()
+
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assertStatus(t, err, codes.Unimplemented, "internal error")
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	assertStatus(t, err, codes.Unimplemented, "internal error")
+}
+
+func TestClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind errcodes.Kind
+		code errcodes.Code
+	}{
+		{"domain error", ErrUserExists, errcodes.Exists, "user_exists"},
+		{"wrapped domain error", fmt.Errorf("create user: %w", ErrUserExists), errcodes.Exists, "user_exists"},
+		{"unknown error", errors.New("db: connection refused"), errcodes.Internal, ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t, tt.err,
+				grpc.WithUnaryInterceptor(grpcerr.UnaryClientInterceptor()),
+				grpc.WithStreamInterceptor(grpcerr.StreamClientInterceptor()),
+			)
+			ctx := context.Background()
+
+			_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			assertError(t, err, tt.kind, tt.code)
+
+			stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = stream.Recv()
+			assertError(t, err, tt.kind, tt.code)
+		})
+	}
+
+	t.Run("field violation codes round trip", func(t *testing.T) {
+		var v errcodes.Validation
+		v.Add("email", "email_invalid", "The email is invalid")
+		v.Add("name", "", "The name is required")
+
+		client := dial(t, v.Err(),
+			grpc.WithUnaryInterceptor(grpcerr.UnaryClientInterceptor()),
+		)
+
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		if got, want := errcodes.Violations(err), v.Violations(); !reflect.DeepEqual(want, got) {
+			t.Fatalf("want violations %v, got %v", want, got)
+		}
+	})
+
+	t.Run("errors.Is matches sentinel", func(t *testing.T) {
+		client := dial(t, ErrUserExists,
+			grpc.WithUnaryInterceptor(grpcerr.UnaryClientInterceptor()),
+		)
+
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		if !errors.Is(err, ErrUserExists) {
+			t.Fatalf("want %v, got %v", ErrUserExists, err)
+		}
+	})
+}
+
+func assertError(t *testing.T, err error, kind errcodes.Kind, code errcodes.Code) {
+	t.Helper()
+
+	var ec *errcodes.Error
+	if !errors.As(err, &ec) {
+		t.Fatalf("want *errcodes.Error, got %T", err)
+	}
+	if want, got := kind, ec.Kind(); want != got {
+		t.Fatalf("want kind %s, got %s", want, got)
+	}
+	if want, got := code, ec.Code(); want != got {
+		t.Fatalf("want code %q, got %q", want, got)
+	}
+}
+
+func assertStatus(t *testing.T, err error, code codes.Code, message string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("want status error, got %v", err)
+	}
+	if want, got := code, st.Code(); want != got {
+		t.Fatalf("want code %s, got %s", want, got)
+	}
+	if want, got := message, st.Message(); want != got {
+		t.Fatalf("want message %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,61 @@
+package httperr
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+// maxBodySize limits the size of the error response body that is decoded.
+const maxBodySize = 1 << 20
+
+// Transport is an http.RoundTripper that decodes error responses into
+// domain errors.
+// Responses with status code below 400 are returned as it is.
+type Transport struct {
+	// Base is the underlying http.RoundTripper.
+	// If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+// RoundTrip satisfies the http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := DecodeResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DecodeResponse returns the domain error for the error response, and nil
+// if the status code is below 400.
+// The kind and code are recovered from the problem details if present,
+// otherwise the kind is derived from the status code.
+// The response body is read, but not closed.
+func DecodeResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	var p Problem
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(&p); err == nil {
+		if kind := errcodes.Kind(p.Kind); kind.Valid() {
+			return errcodes.WithDetails(errcodes.Rehydrate(kind, errcodes.Code(p.Code), p.Title), p.Details...)
+		}
+	}
+
+	return errcodes.Rehydrate(errcodes.KindFromHTTPStatus(resp.StatusCode), "", http.StatusText(resp.StatusCode))
+}
@@ -0,0 +1,104 @@
+// Package httperr renders errors as RFC 9457 problem details.
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/alextanhongpin/errcodes"
+	"golang.org/x/text/language"
+)
+
+// ContentType is the media type of the problem details.
+const ContentType = "application/problem+json"
+
+// Problem is the RFC 9457 problem details for an error.
+// Kind and Code are extension members that allows the client to recover the
+// domain error.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Kind   string `json:"kind,omitempty"`
+	Code   string `json:"code,omitempty"`
+
+	// Details is the structured details of the error.
+	Details errcodes.Details `json:"details,omitempty"`
+}
+
+// NewProblem returns the problem details for the domain error in the error
+// chain.
+// If there is no domain error, the error is classified with
+// errcodes.Classify, and unrecognized errors default to internal server
+// error.
+// The original cause is never exposed.
+func NewProblem(err error, opts ...Option) *Problem {
+	o := newOptions(opts)
+
+	var ec *errcodes.Error
+	if !errors.As(err, &ec) {
+		if errcodes.Classify(err) != errcodes.Unknown {
+			return NewProblem(errcodes.FromError(err), opts...)
+		}
+
+		return &Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: o.mapper.HTTPStatusCode(err),
+			Kind:   string(errcodes.Internal),
+		}
+	}
+
+	typ := string(ec.Code())
+	if typ == "" {
+		typ = "about:blank"
+	}
+
+	// Like the type, the title defaults to the status text, e.g. for errors
+	// without a message.
+	status := o.mapper.HTTPStatusCode(ec)
+	title := ec.Message()
+	if title == "" {
+		title = http.StatusText(status)
+	}
+
+	return &Problem{
+		Type:   typ,
+		Title:  title,
+		Status: status,
+		Detail: ec.Message(),
+		Kind:   string(ec.Kind()),
+		Code:   string(ec.Code()),
+
+		Details: ec.Details(),
+	}
+}
+
+// WriteError writes the error as application/problem+json.
+func WriteError(w http.ResponseWriter, err error, opts ...Option) {
+	p := NewProblem(err, opts...)
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// WriteLocalizedError is like WriteError, but translates the message to the
+// locale from the Accept-Language header using the errcodes.DefaultCatalog.
+func WriteLocalizedError(w http.ResponseWriter, r *http.Request, err error, opts ...Option) {
+	if tag, ok := Locale(r); ok {
+		err = errcodes.Localize(err, tag)
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+	WriteError(w, err, opts...)
+}
+
+// Locale returns the locale in the errcodes.DefaultCatalog that matches
+// the Accept-Language header.
+func Locale(r *http.Request) (language.Tag, bool) {
+	return errcodes.DefaultCatalog.MatchAcceptLanguage(r.Header.Get("Accept-Language"))
+}
@@ -0,0 +1,260 @@
+package httperr_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/alextanhongpin/errcodes"
+	"github.com/alextanhongpin/errcodes/httperr"
+	"golang.org/x/text/language"
+)
+
+var ErrUserExists = errcodes.New(errcodes.Exists, "user_exists", "The user account already exists")
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		body   string
+	}{
+		{
+			name:   "domain error",
+			err:    ErrUserExists,
+			status: http.StatusConflict,
+			body:   `{"type":"user_exists","title":"The user account already exists","status":409,"detail":"The user account already exists","kind":"exists","code":"user_exists"}`,
+		},
+		{
+			name:   "wrapped domain error",
+			err:    fmt.Errorf("create user: %w", ErrUserExists),
+			status: http.StatusConflict,
+			body:   `{"type":"user_exists","title":"The user account already exists","status":409,"detail":"The user account already exists","kind":"exists","code":"user_exists"}`,
+		},
+		{
+			name:   "internal message",
+			err:    errcodes.WithCause(errcodes.WithInternal(ErrUserExists, "email %q is taken", "john.doe@mail.com"), errors.New("db: duplicate key")),
+			status: http.StatusConflict,
+			body:   `{"type":"user_exists","title":"The user account already exists","status":409,"detail":"The user account already exists","kind":"exists","code":"user_exists"}`,
+		},
+		{
+			name:   "classified error",
+			err:    fmt.Errorf("read body: %w", context.Canceled),
+			status: 499,
+			body:   `{"type":"about:blank","title":"The operation was cancelled by the caller","status":499,"detail":"The operation was cancelled by the caller","kind":"cancelled"}`,
+		},
+		{
+			name:   "mixed join",
+			err:    errcodes.Join(errcodes.ErrValidationFailed, errors.New("db: connection refused")),
+			status: http.StatusInternalServerError,
+			body:   `{"type":"about:blank","title":"Internal Server Error","status":500,"kind":"internal"}`,
+		},
+		{
+			name:   "unknown error",
+			err:    errors.New("db: connection refused"),
+			status: http.StatusInternalServerError,
+			body:   `{"type":"about:blank","title":"Internal Server Error","status":500,"kind":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			httperr.WriteError(w, tt.err)
+
+			if want, got := tt.status, w.Code; want != got {
+				t.Fatalf("want status %d, got %d", want, got)
+			}
+			if want, got := httperr.ContentType, w.Header().Get("Content-Type"); want != got {
+				t.Fatalf("want content type %q, got %q", want, got)
+			}
+			if want, got := tt.body+"\n", w.Body.String(); want != got {
+				t.Fatalf("want body %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestWriteErrorWithMapper(t *testing.T) {
+	m := errcodes.NewMapper().SetCodeHTTPStatus("user_exists", http.StatusUnprocessableEntity)
+
+	w := httptest.NewRecorder()
+	httperr.WriteError(w, ErrUserExists, httperr.WithMapper(m))
+	if want, got := http.StatusUnprocessableEntity, w.Code; want != got {
+		t.Fatalf("want status %d, got %d", want, got)
+	}
+
+	w = httptest.NewRecorder()
+	httperr.WriteError(w, ErrUserExists)
+	if want, got := http.StatusConflict, w.Code; want != got {
+		t.Fatalf("want status %d, got %d", want, got)
+	}
+
+	// The fallback for unrecognized errors is also mapped.
+	m = errcodes.NewMapper().SetKindHTTPStatus(errcodes.Internal, 599)
+
+	w = httptest.NewRecorder()
+	httperr.WriteError(w, errors.New("db: connection refused"), httperr.WithMapper(m))
+	if want, got := 599, w.Code; want != got {
+		t.Fatalf("want status %d, got %d", want, got)
+	}
+	if want, got := `{"type":"about:blank","title":"Internal Server Error","status":599,"kind":"internal"}`+"\n", w.Body.String(); want != got {
+		t.Fatalf("want body %s, got %s", want, got)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		kind    errcodes.Kind
+		code    errcodes.Code
+	}{
+		{
+			name: "problem details",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				httperr.WriteError(w, ErrUserExists)
+			},
+			kind: errcodes.Exists,
+			code: "user_exists",
+		},
+		{
+			name: "internal problem details",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				httperr.WriteError(w, errors.New("db: connection refused"))
+			},
+			kind: errcodes.Internal,
+			code: "",
+		},
+		{
+			name: "foreign error body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "rate limited", http.StatusTooManyRequests)
+			},
+			kind: errcodes.TooManyRequests,
+			code: "",
+		},
+		{
+			name: "unmapped status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+			},
+			kind: errcodes.BadRequest,
+			code: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(tt.handler)
+			t.Cleanup(ts.Close)
+
+			client := &http.Client{Transport: &httperr.Transport{}}
+			_, err := client.Get(ts.URL)
+
+			var ec *errcodes.Error
+			if !errors.As(err, &ec) {
+				t.Fatalf("want *errcodes.Error, got %v", err)
+			}
+			if want, got := tt.kind, ec.Kind(); want != got {
+				t.Fatalf("want kind %s, got %s", want, got)
+			}
+			if want, got := tt.code, ec.Code(); want != got {
+				t.Fatalf("want code %q, got %q", want, got)
+			}
+		})
+	}
+
+	t.Run("errors.Is matches sentinel", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httperr.WriteError(w, ErrUserExists)
+		}))
+		t.Cleanup(ts.Close)
+
+		client := &http.Client{Transport: &httperr.Transport{}}
+		_, err := client.Get(ts.URL)
+		if !errors.Is(err, ErrUserExists) {
+			t.Fatalf("want %v, got %v", ErrUserExists, err)
+		}
+	})
+
+	t.Run("details round trip", func(t *testing.T) {
+		want := errcodes.FieldViolations{
+			{Field: "email", Code: "email_invalid", Description: "The email is invalid"},
+			{Field: "name", Description: "The name is required"},
+		}
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httperr.WriteError(w, errcodes.WithDetails(ErrUserExists, want))
+		}))
+		t.Cleanup(ts.Close)
+
+		client := &http.Client{Transport: &httperr.Transport{}}
+		_, err := client.Get(ts.URL)
+
+		var ec *errcodes.Error
+		if !errors.As(err, &ec) {
+			t.Fatalf("want *errcodes.Error, got %v", err)
+		}
+		if got := ec.Details(); !reflect.DeepEqual(errcodes.Details{want}, got) {
+			t.Fatalf("want details %v, got %v", want, got)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(ts.Close)
+
+		client := &http.Client{Transport: &httperr.Transport{}}
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	})
+}
+
+func TestWriteLocalizedError(t *testing.T) {
+	errcodes.DefaultCatalog.Set(language.Malay, "user_exists", "Akaun pengguna sudah wujud")
+
+	tests := []struct {
+		name   string
+		accept string
+		title  string
+	}{
+		{"translated", "ms-MY, en;q=0.8", "Akaun pengguna sudah wujud"},
+		{"no translation", "ja", "The user account already exists"},
+		{"no header", "", "The user account already exists"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept-Language", tt.accept)
+
+			w := httptest.NewRecorder()
+			httperr.WriteLocalizedError(w, r, fmt.Errorf("create user: %w", ErrUserExists))
+
+			var p httperr.Problem
+			if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tt.title, p.Title; want != got {
+				t.Fatalf("want title %q, got %q", want, got)
+			}
+			if want, got := "user_exists", p.Code; want != got {
+				t.Fatalf("want code %q, got %q", want, got)
+			}
+		})
+	}
+}
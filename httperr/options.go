@@ -0,0 +1,29 @@
+package httperr
+
+import "github.com/alextanhongpin/errcodes"
+
+// Option configures the rendering of the problem details.
+type Option func(*options)
+
+type options struct {
+	mapper *errcodes.Mapper
+}
+
+// WithMapper sets the mapper used to map the errors to HTTP status codes.
+// Defaults to errcodes.DefaultMapper.
+func WithMapper(m *errcodes.Mapper) Option {
+	return func(o *options) {
+		o.mapper = m
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		mapper: errcodes.DefaultMapper,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
@@ -0,0 +1,197 @@
+package errcodes
+
+import (
+	"errors"
+	"strings"
+)
+
+// kindPrecedence orders the kinds from the highest precedence to the
+// lowest, and is used to pick the effective kind of a JoinError.
+// Server faults outrank client faults, so a join stays 4xx only if all the
+// errors are client faults.
+var kindPrecedence = []Kind{
+	// Server faults.
+	DataLoss,
+	Internal,
+	Unknown,
+	NotImplemented,
+	Unavailable,
+	DeadlineExceeded,
+
+	// Client faults.
+	Unauthorized,
+	Forbidden,
+	TooManyRequests,
+	Canceled,
+	PreconditionFailed,
+	Aborted,
+	Conflict,
+	Exists,
+	NotFound,
+	OutOfRange,
+	BadRequest,
+}
+
+var rankByKind = func() map[Kind]int {
+	m := make(map[Kind]int)
+	for i, k := range kindPrecedence {
+		m[k] = i
+	}
+	return m
+}()
+
+// JoinError is an aggregate of errors that fail together.
+type JoinError struct {
+	errs []error
+}
+
+// Join returns an error that wraps the given errors, like errors.Join, but
+// with an effective kind picked from the domain errors by precedence.
+// Nil errors are discarded, and Join returns nil if all errors are nil.
+func Join(errs ...error) error {
+	var res []error
+	for _, err := range errs {
+		if err != nil {
+			res = append(res, err)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+
+	return &JoinError{errs: res}
+}
+
+// Error satisfies the error interface.
+func (e *JoinError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the joined errors.
+func (e *JoinError) Unwrap() []error {
+	return e.errs
+}
+
+// Errors returns the domain errors, in the order they are joined.
+// Errors without a domain error are skipped.
+func (e *JoinError) Errors() []*Error {
+	var res []*Error
+	for _, err := range e.errs {
+		res = append(res, members(err, false)...)
+	}
+
+	return res
+}
+
+// Kind returns the effective kind of the joined errors.
+// Errors without a domain error are ranked by their classified kind, and
+// unrecognized errors are Internal, like a lone unrecognized error, so they
+// outrank every client fault.
+func (e *JoinError) Kind() Kind {
+	ec := e.effective()
+	if ec == nil {
+		return Unknown
+	}
+
+	return ec.kind
+}
+
+// As allows errors.As to find the domain error with the effective kind,
+// so that the HTTP and gRPC mapping uses the effective kind.
+// If the effective kind is from an unrecognized error, the domain error is
+// Internal without a message, so that the cause is never exposed.
+func (e *JoinError) As(target any) bool {
+	t, ok := target.(**Error)
+	if !ok {
+		return false
+	}
+
+	ec := e.effective()
+	if ec == nil {
+		return false
+	}
+	*t = ec
+
+	return true
+}
+
+// errUnrecognized is the effective error of a join for the errors that are
+// not recognized. Like a lone unrecognized error, it is Internal, and has no
+// message, so that the transports fall back to their own.
+var errUnrecognized = &Error{kind: Internal}
+
+// effective returns the first error with the highest precedence.
+// Errors without a domain error are classified with FromError, and
+// unrecognized errors are errUnrecognized, which ties with the Internal
+// domain errors.
+func (e *JoinError) effective() *Error {
+	var res *Error
+	for _, ec := range members(e, true) {
+		if res == nil || outranks(ec.kind, res.kind) || (res == errUnrecognized && ec.kind == Internal) {
+			res = ec
+		}
+	}
+
+	return res
+}
+
+// outranks reports whether kind a has a higher precedence than kind b.
+// Custom kinds are ranked after the built-in kinds of the same fault, so a
+// custom server fault still outranks every client fault, and custom kinds
+// are ranked among themselves by severity.
+func outranks(a, b Kind) bool {
+	if sa, sb := a.IsServerFault(), b.IsServerFault(); sa != sb {
+		return sa
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra < rb
+	}
+
+	return a.Severity() > b.Severity()
+}
+
+func rank(kind Kind) int {
+	r, ok := rankByKind[kind]
+	if !ok {
+		return len(kindPrecedence)
+	}
+
+	return r
+}
+
+// members returns the domain errors in the error tree.
+// If classify is true, the leaves without a domain error are classified
+// with FromError instead of skipped.
+func members(err error, classify bool) []*Error {
+	switch e := err.(type) {
+	case *Error:
+		return []*Error{e}
+	case *replacedError:
+		return []*Error{e.ec}
+	case interface{ Unwrap() []error }:
+		var res []*Error
+		for _, err := range e.Unwrap() {
+			res = append(res, members(err, classify)...)
+		}
+		return res
+	}
+
+	if next := errors.Unwrap(err); next != nil {
+		return members(next, classify)
+	}
+
+	if classify {
+		if ec := FromError(err).(*Error); ec.kind != Unknown {
+			return []*Error{ec}
+		}
+
+		return []*Error{errUnrecognized}
+	}
+
+	return nil
+}
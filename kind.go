@@ -0,0 +1,109 @@
+package errcodes
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Retryable returns true if the error can be retried.
+func Retryable(err error) bool {
+	return err != nil && Classify(err).IsRetryable()
+}
+
+// ClientFault returns true if the error is caused by the caller.
+func ClientFault(err error) bool {
+	return err != nil && Classify(err).IsClientFault()
+}
+
+// ServerFault returns true if the error is caused by the server.
+func ServerFault(err error) bool {
+	return err != nil && Classify(err).IsServerFault()
+}
+
+// SeverityOf returns the severity of the error.
+func SeverityOf(err error) Severity {
+	if err == nil {
+		return SeverityInfo
+	}
+
+	return Classify(err).Severity()
+}
+
+var ErrKindExists = errors.New("errcodes: kind already exists")
+
+// kindsMu guards the kind tables, which can be extended with RegisterKind.
+var kindsMu sync.RWMutex
+
+// KindInfo describes a custom kind.
+type KindInfo struct {
+	// HTTPStatus is the HTTP status code of the kind.
+	// Status codes of 500 and above are server faults.
+	HTTPStatus int
+
+	// GRPCCode is the gRPC code of the kind.
+	// It must not be codes.OK.
+	GRPCCode codes.Code
+
+	Description string
+	Retryable   bool
+	Severity    Severity
+}
+
+// RegisterKind registers a custom kind with its HTTP status and gRPC code,
+// so that it passes validation and flows through HTTPStatusCode and GRPCCode.
+// It returns ErrKindExists if the kind is a built-in kind or is already
+// registered.
+// KindFromHTTPStatus only maps to the custom kind if no other kind maps to
+// the same HTTP status. KindFromGRPCCode never maps to a custom kind, since
+// every gRPC code is already mapped to a built-in kind, so a custom kind is
+// lost when an error crosses a gRPC boundary without its details.
+func RegisterKind(kind Kind, info KindInfo) error {
+	if kind == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidKind, kind)
+	}
+
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+
+	if _, ok := kindInfoByKind[kind]; ok || kind.builtin() {
+		return fmt.Errorf("%w: %q", ErrKindExists, kind)
+	}
+
+	if info.HTTPStatus < 400 || info.HTTPStatus > 599 {
+		return fmt.Errorf("errcodes: invalid http status %d for kind %q", info.HTTPStatus, kind)
+	}
+	if info.GRPCCode < codes.Canceled || info.GRPCCode > codes.Unauthenticated {
+		return fmt.Errorf("errcodes: invalid grpc code %s for kind %q", info.GRPCCode, kind)
+	}
+
+	kindInfoByKind[kind] = kindInfo{
+		description: info.Description,
+		retryable:   info.Retryable,
+		serverFault: info.HTTPStatus >= 500,
+		severity:    info.Severity,
+	}
+	httpStatusByKind[kind] = info.HTTPStatus
+	grpcCodeByKind[kind] = info.GRPCCode
+	if _, ok := kindByHTTPStatus[info.HTTPStatus]; !ok {
+		kindByHTTPStatus[info.HTTPStatus] = kind
+	}
+
+	return nil
+}
+
+// MustRegisterKind is like RegisterKind, but panics on error.
+// It returns the kind, so that the kind can be declared as a package-level
+// var, which is initialized before the errors that depend on it, e.g.
+//
+//	var PaymentRequired = errcodes.MustRegisterKind("payment_required", errcodes.KindInfo{...})
+//	var ErrSubscriptionExpired = errcodes.New(PaymentRequired, "subscription_expired", "The subscription has expired")
+func MustRegisterKind(kind Kind, info KindInfo) Kind {
+	if err := RegisterKind(kind, info); err != nil {
+		panic(err)
+	}
+
+	return kind
+}
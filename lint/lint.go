@@ -0,0 +1,231 @@
+// Package lint provides an analyzer that checks errcodes declarations.
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+
+	"github.com/alextanhongpin/errcodes"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const errcodesPath = "github.com/alextanhongpin/errcodes"
+
+// Analyzer reports errcodes.New calls with non-constant or invalid kind,
+// calls outside of package-level var declarations, and duplicate codes.
+// Custom kinds are valid if they are declared as package-level vars, or as
+// constants that are registered with errcodes.RegisterKind or
+// errcodes.MustRegisterKind in the declaring package.
+var Analyzer = &analysis.Analyzer{
+	Name:      "errcodes",
+	Doc:       "check errcodes.New declarations",
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(codesFact), new(kindFact)},
+}
+
+// codesFact records the codes declared in a package, keyed by code with
+// the position of the declaration.
+type codesFact struct {
+	Codes map[string]string
+}
+
+func (*codesFact) AFact() {}
+
+func (f *codesFact) String() string {
+	return fmt.Sprintf("codes(%d)", len(f.Codes))
+}
+
+// kindFact marks a Kind constant that is registered as a custom kind.
+type kindFact struct{}
+
+func (*kindFact) AFact() {}
+
+func (*kindFact) String() string {
+	return "registered"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	kinds := registeredKinds(pass, inspect)
+
+	// Codes declared by the dependencies.
+	deps := make(map[string]string)
+	var depCodes []string
+	for _, f := range pass.AllPackageFacts() {
+		if f.Package == pass.Pkg {
+			continue
+		}
+		for code, pos := range f.Fact.(*codesFact).Codes {
+			if prev, ok := deps[code]; ok && prev != pos && pass.Pkg.Name() == "main" {
+				depCodes = append(depCodes, fmt.Sprintf("duplicate code %q declared at %s and %s", code, prev, pos))
+			}
+			deps[code] = pos
+		}
+	}
+
+	// Report conflicts between dependencies once, in the main package
+	// that links them together.
+	if len(depCodes) > 0 && len(pass.Files) > 0 {
+		sort.Strings(depCodes)
+		for _, msg := range depCodes {
+			pass.Reportf(pass.Files[0].Package, "%s", msg)
+		}
+	}
+
+	codes := make(map[string]string)
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil)}
+	inspect.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return false
+		}
+
+		call := n.(*ast.CallExpr)
+		if !isFunc(pass.TypesInfo, call, "New") || len(call.Args) != 3 {
+			return true
+		}
+
+		if inFunc(stack) {
+			pass.Reportf(call.Pos(), "errcodes.New must be called in a package-level var declaration")
+		}
+
+		checkKind(pass, kinds, call.Args[0])
+
+		code, ok := constString(pass.TypesInfo, call.Args[1])
+		if !ok {
+			return true
+		}
+
+		pos := pass.Fset.Position(call.Args[1].Pos()).String()
+		if prev, ok := codes[code]; ok {
+			pass.Reportf(call.Args[1].Pos(), "duplicate code %q, also declared at %s", code, prev)
+		} else if prev, ok := deps[code]; ok {
+			pass.Reportf(call.Args[1].Pos(), "duplicate code %q, also declared at %s", code, prev)
+		} else {
+			codes[code] = pos
+		}
+
+		return true
+	})
+
+	if len(codes) > 0 {
+		pass.ExportPackageFact(&codesFact{Codes: codes})
+	}
+
+	return nil, nil
+}
+
+func checkKind(pass *analysis.Pass, kinds map[string]bool, arg ast.Expr) {
+	kind, ok := constString(pass.TypesInfo, arg)
+	if !ok {
+		// Custom kinds registered with errcodes.MustRegisterKind are
+		// declared as package-level vars.
+		if !isPackageVar(pass.TypesInfo, arg) {
+			pass.Reportf(arg.Pos(), "errcodes.New must be called with a constant kind")
+		}
+		return
+	}
+
+	if errcodes.Kind(kind).Valid() || kinds[kind] {
+		return
+	}
+
+	// Constants registered as custom kinds in their own package.
+	if obj, ok := objectOf(pass.TypesInfo, arg).(*types.Const); ok && pass.ImportObjectFact(obj, new(kindFact)) {
+		return
+	}
+
+	pass.Reportf(arg.Pos(), "invalid kind %q", kind)
+}
+
+// registeredKinds returns the constant kinds passed to errcodes.RegisterKind
+// and errcodes.MustRegisterKind in the package, and marks the package-level
+// Kind constants with those values with a kindFact.
+func registeredKinds(pass *analysis.Pass, inspect *inspector.Inspector) map[string]bool {
+	kinds := make(map[string]bool)
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil)}
+	inspect.Preorder(nodes, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if !isFunc(pass.TypesInfo, call, "RegisterKind") && !isFunc(pass.TypesInfo, call, "MustRegisterKind") {
+			return
+		}
+		if len(call.Args) == 0 {
+			return
+		}
+
+		if kind, ok := constString(pass.TypesInfo, call.Args[0]); ok {
+			kinds[kind] = true
+		}
+	})
+
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || c.Val().Kind() != constant.String || !kinds[constant.StringVal(c.Val())] {
+			continue
+		}
+
+		if named, ok := c.Type().(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == errcodesPath && named.Obj().Name() == "Kind" {
+			pass.ExportObjectFact(c, new(kindFact))
+		}
+	}
+
+	return kinds
+}
+
+func isPackageVar(info *types.Info, expr ast.Expr) bool {
+	v, ok := objectOf(info, expr).(*types.Var)
+	return ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+func objectOf(info *types.Info, expr ast.Expr) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return info.Uses[e]
+	case *ast.SelectorExpr:
+		return info.Uses[e.Sel]
+	default:
+		return nil
+	}
+}
+
+// isFunc returns true if the call is a call to the errcodes function with
+// the given name.
+func isFunc(info *types.Info, call *ast.CallExpr, name string) bool {
+	obj, ok := objectOf(info, call.Fun).(*types.Func)
+	if !ok || obj.Pkg() == nil {
+		return false
+	}
+
+	return obj.Pkg().Path() == errcodesPath && obj.Name() == name
+}
+
+// inFunc returns true if the node is declared inside a function, where a
+// sentinel error would be created on every call.
+func inFunc(stack []ast.Node) bool {
+	for _, n := range stack {
+		switch n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return true
+		}
+	}
+
+	return false
+}
+
+func constString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
@@ -0,0 +1,38 @@
+package a // want package:"codes\\(9\\)"
+
+import "github.com/alextanhongpin/errcodes"
+
+var (
+	ErrUserExists   = errcodes.New(errcodes.Exists, "user_exists", "The user account already exists")
+	ErrUserNotFound = errcodes.New("not_found", "user_not_found", "The user does not exist")
+	ErrInvalidKind  = errcodes.New("exist", "invalid_kind", "Invalid kind")                           // want `invalid kind "exist"`
+	ErrDuplicate    = errcodes.New(errcodes.Exists, "user_exists", "The user account already exists") // want `duplicate code "user_exists", also declared at .+`
+)
+
+func kind() errcodes.Kind {
+	return errcodes.Exists
+}
+
+var ErrNonConstantKind = errcodes.New(kind(), "non_constant_kind", "Non constant kind") // want `errcodes.New must be called with a constant kind`
+
+const Gone errcodes.Kind = "gone" // want Gone:"registered"
+
+var _ = errcodes.MustRegisterKind(Gone, errcodes.KindInfo{})
+
+var ErrCustomConstKind = errcodes.New(Gone, "custom_const_kind", "Custom kind")
+
+const Typo errcodes.Kind = "exsts"
+
+var ErrTypoConstKind = errcodes.New(Typo, "typo_const_kind", "Typo kind") // want `invalid kind "exsts"`
+
+var PaymentRequired = errcodes.Kind("payment_required")
+
+var ErrCustomVarKind = errcodes.New(PaymentRequired, "custom_var_kind", "Custom kind")
+
+var ErrFuncLit = func() error {
+	return errcodes.New(errcodes.Exists, "func_lit", "Function literal") // want `errcodes.New must be called in a package-level var declaration`
+}()
+
+func NewError() error {
+	return errcodes.New(errcodes.NotFound, "in_func", "Inside function") // want `errcodes.New must be called in a package-level var declaration`
+}
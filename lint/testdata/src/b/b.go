@@ -0,0 +1,11 @@
+package b // want package:"codes\\(1\\)"
+
+import (
+	"a"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+var ErrUserExists = errcodes.New(errcodes.Exists, "user_exists", "The user account already exists") // want `duplicate code "user_exists", also declared at .+a.go.+`
+
+var ErrGone = errcodes.New(a.Gone, "b_gone", "Registered in the declaring package")
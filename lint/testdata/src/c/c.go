@@ -0,0 +1,5 @@
+package c
+
+import "github.com/alextanhongpin/errcodes"
+
+var ErrUserNotFound = errcodes.New(errcodes.NotFound, "user_not_found", "The user does not exist")
@@ -0,0 +1,8 @@
+package main // want `duplicate code "user_not_found" declared at .+ and .+`
+
+import (
+	_ "a"
+	_ "c"
+)
+
+func main() {}
@@ -0,0 +1,28 @@
+package errcodes
+
+type Code string
+
+type Kind string
+
+const (
+	Exists   Kind = "exists"
+	NotFound Kind = "not_found"
+)
+
+func (c Kind) Valid() bool {
+	return c == Exists || c == NotFound
+}
+
+func New(kind Kind, code Code, message string) error {
+	return nil
+}
+
+type KindInfo struct{}
+
+func RegisterKind(kind Kind, info KindInfo) error {
+	return nil
+}
+
+func MustRegisterKind(kind Kind, info KindInfo) Kind {
+	return kind
+}
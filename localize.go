@@ -0,0 +1,163 @@
+package errcodes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+var ErrMissingTranslation = errors.New("errcodes: missing translation")
+
+// DefaultCatalog is the catalog used by Localize.
+var DefaultCatalog = NewCatalog()
+
+// Catalog holds the translated messages keyed by locale and code.
+type Catalog struct {
+	mu       sync.RWMutex
+	tags     []language.Tag
+	messages map[language.Tag]map[Code]string
+	matcher  language.Matcher
+}
+
+// NewCatalog returns a new empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		messages: make(map[language.Tag]map[Code]string),
+	}
+}
+
+// Set sets the translated message for the code in the given locale.
+func (c *Catalog) Set(tag language.Tag, code Code, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m, ok := c.messages[tag]
+	if !ok {
+		m = make(map[Code]string)
+		c.messages[tag] = m
+		c.tags = append(c.tags, tag)
+		c.matcher = language.NewMatcher(c.tags)
+	}
+	m[code] = message
+}
+
+// Tags returns the locales in the catalog.
+func (c *Catalog) Tags() []language.Tag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return append([]language.Tag(nil), c.tags...)
+}
+
+// Match returns the locale in the catalog that best matches the preferred
+// locales.
+// It returns false if none of the locales matches.
+func (c *Catalog) Match(prefs ...language.Tag) (language.Tag, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.matcher == nil {
+		return language.Und, false
+	}
+
+	_, i, conf := c.matcher.Match(prefs...)
+	if conf == language.No {
+		return language.Und, false
+	}
+
+	return c.tags[i], true
+}
+
+// MatchAcceptLanguage is like Match, but takes the preferred locales from
+// the Accept-Language header value.
+func (c *Catalog) MatchAcceptLanguage(accept string) (language.Tag, bool) {
+	prefs, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil || len(prefs) == 0 {
+		return language.Und, false
+	}
+
+	return c.Match(prefs...)
+}
+
+// Localize returns the domain error in the error chain with the message
+// translated to the given locale.
+// For a template instance, the translation is a template rendered with the
+// params of the instance, e.g. "Akaun {{.ID}} sudah wujud".
+// If there is no translation, or it cannot be rendered, the error is
+// returned as it is, so the original message is the fallback.
+func (c *Catalog) Localize(err error, tag language.Tag) error {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return err
+	}
+
+	tag, ok := c.Match(tag)
+	if !ok {
+		return err
+	}
+
+	c.mu.RLock()
+	msg, ok := c.messages[tag][ec.code]
+	c.mu.RUnlock()
+	if !ok {
+		return err
+	}
+
+	// Template instances render their params into the translation.
+	if ec.params != nil {
+		if msg, ok = render(ec.code, msg, ec.params); !ok {
+			return err
+		}
+	}
+
+	return ec.withMessage(msg)
+}
+
+// Missing returns the codes in the registry that have no translation,
+// keyed by locale.
+func (c *Catalog) Missing(r *Registry) map[language.Tag][]Code {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	res := make(map[language.Tag][]Code)
+	for _, err := range r.All() {
+		for _, tag := range c.tags {
+			if _, ok := c.messages[tag][err.code]; !ok {
+				res[tag] = append(res[tag], err.code)
+			}
+		}
+	}
+
+	return res
+}
+
+// Validate reports the codes in the registry that have no translation.
+// It is usually called at startup, after all errors are declared.
+func (c *Catalog) Validate(r *Registry) error {
+	missing := c.Missing(r)
+
+	tags := make([]language.Tag, 0, len(missing))
+	for tag := range missing {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].String() < tags[j].String()
+	})
+
+	var errs []error
+	for _, tag := range tags {
+		for _, code := range missing[tag] {
+			errs = append(errs, fmt.Errorf("%w: %q in %s", ErrMissingTranslation, code, tag))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Localize localizes the error using the DefaultCatalog.
+func Localize(err error, tag language.Tag) error {
+	return DefaultCatalog.Localize(err, tag)
+}
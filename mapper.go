@@ -0,0 +1,124 @@
+package errcodes
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMapper maps the errors using the default kind mappings.
+var DefaultMapper = NewMapper()
+
+// Mapper maps errors to HTTP status and gRPC codes, with overrides per kind
+// and per code on top of HTTPStatusCode and GRPCCode.
+// Code overrides take precedence over kind overrides.
+// The overrides should be set before the mapper is used, as they are not
+// safe for concurrent modification.
+type Mapper struct {
+	httpStatusByKind map[Kind]int
+	grpcCodeByKind   map[Kind]codes.Code
+	httpStatusByCode map[Code]int
+	grpcCodeByCode   map[Code]codes.Code
+}
+
+// NewMapper returns a new mapper built from the defaults.
+func NewMapper() *Mapper {
+	return &Mapper{
+		httpStatusByKind: make(map[Kind]int),
+		grpcCodeByKind:   make(map[Kind]codes.Code),
+		httpStatusByCode: make(map[Code]int),
+		grpcCodeByCode:   make(map[Code]codes.Code),
+	}
+}
+
+// SetKindHTTPStatus overrides the HTTP status for the kind.
+func (m *Mapper) SetKindHTTPStatus(kind Kind, status int) *Mapper {
+	m.httpStatusByKind[kind] = status
+	return m
+}
+
+// SetKindGRPCCode overrides the gRPC code for the kind.
+func (m *Mapper) SetKindGRPCCode(kind Kind, code codes.Code) *Mapper {
+	m.grpcCodeByKind[kind] = code
+	return m
+}
+
+// SetCodeHTTPStatus overrides the HTTP status for the code.
+func (m *Mapper) SetCodeHTTPStatus(code Code, status int) *Mapper {
+	m.httpStatusByCode[code] = status
+	return m
+}
+
+// SetCodeGRPCCode overrides the gRPC code for the code.
+func (m *Mapper) SetCodeGRPCCode(code Code, grpcCode codes.Code) *Mapper {
+	m.grpcCodeByCode[code] = grpcCode
+	return m
+}
+
+// HTTPStatusCode returns the HTTP status code for the error.
+// Errors without a domain error are classified with Classify, and
+// unrecognized errors are mapped as Internal.
+func (m *Mapper) HTTPStatusCode(err error) int {
+	var ec *Error
+	if errors.As(err, &ec) {
+		if status, ok := m.httpStatusByCode[ec.code]; ok {
+			return status
+		}
+	}
+
+	kind := mapperKind(err)
+	if status, ok := m.httpStatusByKind[kind]; ok {
+		return status
+	}
+
+	return HTTPStatusCode(kind)
+}
+
+// GRPCCode returns the gRPC code for the error.
+// Errors without a domain error are classified with Classify, and
+// unrecognized errors are mapped as Internal.
+func (m *Mapper) GRPCCode(err error) codes.Code {
+	var ec *Error
+	if errors.As(err, &ec) {
+		if code, ok := m.grpcCodeByCode[ec.code]; ok {
+			return code
+		}
+	}
+
+	kind := mapperKind(err)
+	if code, ok := m.grpcCodeByKind[kind]; ok {
+		return code
+	}
+
+	return GRPCCode(kind)
+}
+
+// mapperKind returns the kind used to map the error.
+// Unlike Classify, errors without a domain error that are not recognized
+// are Internal, since their cause is never exposed.
+func mapperKind(err error) Kind {
+	kind := Classify(err)
+	if kind != Unknown {
+		return kind
+	}
+
+	var ec *Error
+	if errors.As(err, &ec) {
+		return kind
+	}
+
+	return Internal
+}
+
+// GRPCStatus returns the gRPC status for the domain error in the error
+// chain, using the mapped gRPC code.
+// It returns nil if there is no domain error.
+func (m *Mapper) GRPCStatus(err error) *status.Status {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return nil
+	}
+
+	return ec.grpcStatus(m.GRPCCode(ec))
+}
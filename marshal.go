@@ -0,0 +1,128 @@
+package errcodes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+// The kind is not validated, so that an unset kind can still be encoded.
+// Invalid kinds are rejected when decoding instead.
+func (c Kind) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+// Both "canceled" and "cancelled" are accepted for Canceled.
+// It returns ErrInvalidKind if the kind is invalid.
+func (c *Kind) UnmarshalText(b []byte) error {
+	kind := Kind(b)
+	if kind == "canceled" {
+		kind = Canceled
+	}
+
+	if !kind.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidKind, string(b))
+	}
+	*c = kind
+
+	return nil
+}
+
+// Scan satisfies the sql.Scanner interface.
+func (c *Kind) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return c.UnmarshalText([]byte(v))
+	case []byte:
+		return c.UnmarshalText(v)
+	default:
+		return fmt.Errorf("errcodes: cannot scan %T into Kind", src)
+	}
+}
+
+// Value satisfies the driver.Valuer interface.
+// Like MarshalText, the kind is not validated.
+func (c Kind) Value() (driver.Value, error) {
+	return string(c), nil
+}
+
+// Scan satisfies the sql.Scanner interface.
+func (c *Code) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		*c = Code(v)
+	case []byte:
+		*c = Code(v)
+	default:
+		return fmt.Errorf("errcodes: cannot scan %T into Code", src)
+	}
+
+	return nil
+}
+
+// Value satisfies the driver.Valuer interface.
+func (c Code) Value() (driver.Value, error) {
+	return string(c), nil
+}
+
+type errorJSON struct {
+	Kind     string  `json:"kind"`
+	Code     Code    `json:"code"`
+	Message  string  `json:"message"`
+	Internal string  `json:"internal,omitempty"`
+	Params   any     `json:"params,omitempty"`
+	Details  Details `json:"details,omitempty"`
+	Cause    string  `json:"cause,omitempty"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// The cause, if any, is marshalled as a string.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	v := errorJSON{
+		Kind:     string(e.kind),
+		Code:     e.code,
+		Message:  e.message,
+		Internal: e.internal,
+		Params:   e.params,
+		Details:  e.details,
+	}
+	if e.cause != nil {
+		v.Cause = e.cause.Error()
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+// The unmarshalled error compares equal to the original error under
+// errors.Is, but is not registered.
+// Like Rehydrate, an invalid kind, e.g. a custom kind that is not
+// registered in this process, becomes Unknown.
+func (e *Error) UnmarshalJSON(b []byte) error {
+	var v errorJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	var kind Kind
+	if err := kind.UnmarshalText([]byte(v.Kind)); err != nil {
+		kind = Unknown
+	}
+
+	*e = Error{
+		kind:     kind,
+		code:     v.Code,
+		message:  v.Message,
+		internal: v.Internal,
+		params:   v.Params,
+		details:  v.Details,
+	}
+	if v.Cause != "" {
+		e.cause = errors.New(v.Cause)
+	}
+
+	return nil
+}
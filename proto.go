@@ -0,0 +1,88 @@
+package errcodes
+
+import (
+	"errors"
+
+	"github.com/alextanhongpin/errcodes/errcodespb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ToProto returns the protobuf message for the error, including the
+// details and the cause chain.
+// The codes of the field violations are carried in the metadata.
+// Errors that are not domain errors only carry the message.
+// It returns nil if the error is nil.
+func ToProto(err error) *errcodespb.Error {
+	if err == nil {
+		return nil
+	}
+
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return &errcodespb.Error{
+			Message: err.Error(),
+		}
+	}
+
+	pb := &errcodespb.Error{
+		Kind:     string(ec.kind),
+		Code:     string(ec.code),
+		Message:  ec.message,
+		Internal: ec.internal,
+		Cause:    ToProto(ec.cause),
+	}
+	for _, d := range ec.details {
+		a, err := anypb.New(d.proto())
+		if err != nil {
+			continue
+		}
+		pb.Details = append(pb.Details, a)
+	}
+	if metadata := ec.details.fieldViolationCodes(); len(metadata) > 0 {
+		pb.Metadata = metadata
+	}
+
+	return pb
+}
+
+// FromProto returns the error for the protobuf message.
+// Like Rehydrate, the error is not registered, and an invalid kind becomes
+// Unknown.
+// Unknown detail types are skipped.
+// It returns nil if the message is nil.
+func FromProto(pb *errcodespb.Error) error {
+	if pb == nil {
+		return nil
+	}
+
+	if pb.GetKind() == "" {
+		return errors.New(pb.GetMessage())
+	}
+
+	kind := Kind(pb.GetKind())
+	if !kind.Valid() {
+		kind = Unknown
+	}
+
+	var details Details
+	for _, a := range pb.GetDetails() {
+		m, err := a.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+
+		if detail, ok := detailFromProto(m); ok {
+			details = append(details, detail)
+		}
+	}
+	details.setFieldViolationCodes(pb.GetMetadata())
+
+	return &Error{
+		kind:     kind,
+		code:     Code(pb.GetCode()),
+		message:  pb.GetMessage(),
+		internal: pb.GetInternal(),
+		details:  details,
+		cause:    FromProto(pb.GetCause()),
+	}
+}
@@ -0,0 +1,120 @@
+package errcodes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrCodeConflict   = errors.New("errcodes: conflicting code")
+	ErrRegistryFrozen = errors.New("errcodes: registry is frozen")
+)
+
+// DefaultRegistry is the registry that New registers into.
+var DefaultRegistry = NewRegistry()
+
+// Registry is a catalog of errors keyed by code.
+type Registry struct {
+	mu     sync.RWMutex
+	errs   map[Code]*Error
+	frozen bool
+}
+
+// NewRegistry returns a new empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		errs: make(map[Code]*Error),
+	}
+}
+
+// Register adds the error to the registry.
+// Redeclaring the same code with the same kind and message is allowed, but
+// redeclaring it with a different kind or message returns ErrCodeConflict.
+func (r *Registry) Register(err *Error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.frozen {
+		return fmt.Errorf("%w: cannot register %q", ErrRegistryFrozen, err.code)
+	}
+
+	if prev, ok := r.errs[err.code]; ok {
+		if prev.kind != err.kind || prev.message != err.message {
+			return fmt.Errorf("%w: %q is declared as %q and %q", ErrCodeConflict, err.code, prev, err)
+		}
+
+		return nil
+	}
+
+	r.errs[err.code] = err
+
+	return nil
+}
+
+// MustRegister is like Register, but panics on error.
+func (r *Registry) MustRegister(err *Error) {
+	if err := r.Register(err); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the error registered with the given code.
+func (r *Registry) Lookup(code Code) (*Error, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err, ok := r.errs[code]
+	return err, ok
+}
+
+// All returns all registered errors, sorted by code.
+func (r *Registry) All() []*Error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]*Error, 0, len(r.errs))
+	for _, err := range r.errs {
+		res = append(res, err)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].code < res[j].code
+	})
+
+	return res
+}
+
+// Freeze prevents further registration.
+// It is usually called after init, so that the catalog stays stable at
+// runtime.
+func (r *Registry) Freeze() {
+	r.mu.Lock()
+	r.frozen = true
+	r.mu.Unlock()
+}
+
+// Frozen returns true if the registry is frozen.
+func (r *Registry) Frozen() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.frozen
+}
+
+// Lookup returns the error registered with the given code in the default
+// registry.
+func Lookup(code Code) (*Error, bool) {
+	return DefaultRegistry.Lookup(code)
+}
+
+// All returns all errors registered in the default registry.
+func All() []*Error {
+	return DefaultRegistry.All()
+}
+
+// Freeze freezes the default registry.
+func Freeze() {
+	DefaultRegistry.Freeze()
+}
@@ -0,0 +1,143 @@
+package errcodes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Clock is the clock used by Retry to wait between attempts.
+// It can be replaced in tests so that they never sleep for real.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RetryPolicy configures Retry.
+// The zero value is a valid policy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Defaults to 3.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubled on every
+	// subsequent retry. Defaults to 100ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between attempts. Defaults to 10s.
+	MaxDelay time.Duration
+
+	// Retryable decides whether the error should be retried.
+	// Defaults to Retryable, which retries Unavailable, DeadlineExceeded,
+	// Aborted and TooManyRequests.
+	Retryable func(err error) bool
+
+	// Clock defaults to the real clock.
+	Clock Clock
+
+	// Rand returns a number in [0, 1) used for jitter.
+	// Defaults to rand.Float64.
+	Rand func() float64
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 100 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 10 * time.Second
+	}
+	if p.Retryable == nil {
+		p.Retryable = Retryable
+	}
+	if p.Clock == nil {
+		p.Clock = realClock{}
+	}
+	if p.Rand == nil {
+		p.Rand = rand.Float64
+	}
+
+	return p
+}
+
+// delay returns the exponential backoff with jitter for the nth retry,
+// starting from 0.
+// The retry delay hint of the error, if any, takes precedence.
+func (p RetryPolicy) delay(n int, err error) time.Duration {
+	if d, ok := retryDelay(err); ok {
+		return d
+	}
+
+	// Clamp before shifting, so that the backoff never overflows.
+	d := p.MaxDelay
+	if p.BaseDelay <= p.MaxDelay>>n {
+		d = p.BaseDelay << n
+	}
+
+	// Equal jitter, keeps at least half of the backoff.
+	half := d / 2
+	return half + time.Duration(p.Rand()*float64(d-half))
+}
+
+// Retry calls fn until it succeeds, the error is not retryable, the
+// attempts are exhausted, or the context is done.
+// When the context is done, the returned error wraps both the context error
+// and the last error.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	p := policy.withDefaults()
+
+	var err error
+	for n := 0; n < p.MaxAttempts; n++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return retryContextError(ctxErr, err)
+		}
+
+		err = fn(ctx)
+		if err == nil || !p.Retryable(err) || n == p.MaxAttempts-1 {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return retryContextError(ctx.Err(), err)
+		case <-p.Clock.After(p.delay(n, err)):
+		}
+	}
+
+	return err
+}
+
+func retryContextError(ctxErr, err error) error {
+	if err == nil {
+		return ctxErr
+	}
+
+	return fmt.Errorf("%w: %w", ctxErr, err)
+}
+
+// retryDelay returns the retry delay hint from the RetryInfo detail of the
+// domain error.
+func retryDelay(err error) (time.Duration, bool) {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return 0, false
+	}
+
+	for _, d := range ec.details {
+		if info, ok := d.(RetryInfo); ok {
+			return info.RetryDelay, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,164 @@
+package errcodes_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alextanhongpin/errcodes"
+)
+
+var ErrServiceUnavailable = errcodes.New(errcodes.Unavailable, "service_unavailable", "The service is unavailable")
+
+type fakeClock struct {
+	delays []time.Duration
+	cancel func()
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	if c.cancel != nil {
+		c.cancel()
+		return nil
+	}
+
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     []error
+		attempts int
+		delays   []time.Duration
+		err      error
+	}{
+		{
+			name:     "success",
+			errs:     []error{nil},
+			attempts: 1,
+		},
+		{
+			name:     "retry until success",
+			errs:     []error{ErrServiceUnavailable, ErrServiceUnavailable, nil},
+			attempts: 3,
+			delays:   []time.Duration{75 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name:     "exhausted",
+			errs:     []error{ErrServiceUnavailable, ErrServiceUnavailable, ErrServiceUnavailable},
+			attempts: 3,
+			delays:   []time.Duration{75 * time.Millisecond, 150 * time.Millisecond},
+			err:      ErrServiceUnavailable,
+		},
+		{
+			name:     "not retryable",
+			errs:     []error{ErrUserExists},
+			attempts: 1,
+			err:      ErrUserExists,
+		},
+		{
+			name:     "not retryable unknown error",
+			errs:     []error{errors.New("boom")},
+			attempts: 1,
+			err:      errors.New("boom"),
+		},
+		{
+			name: "retry delay hint",
+			errs: []error{
+				errcodes.WithDetails(ErrServiceUnavailable, errcodes.RetryInfo{RetryDelay: 3 * time.Second}),
+				nil,
+			},
+			attempts: 2,
+			delays:   []time.Duration{3 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			clock := new(fakeClock)
+			policy := errcodes.RetryPolicy{
+				Clock: clock,
+				Rand:  func() float64 { return 0.5 },
+			}
+
+			var attempts int
+			err := errcodes.Retry(context.Background(), policy, func(ctx context.Context) error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+
+			if want, got := tt.attempts, attempts; want != got {
+				t.Fatalf("want %d attempts, got %d", want, got)
+			}
+			if want, got := tt.delays, clock.delays; !reflect.DeepEqual(want, got) {
+				t.Fatalf("want delays %v, got %v", want, got)
+			}
+			if tt.err == nil && err != nil {
+				t.Fatalf("want nil error, got %v", err)
+			}
+			if tt.err != nil && (err == nil || err.Error() != tt.err.Error()) {
+				t.Fatalf("want error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRetryMaxDelay(t *testing.T) {
+	clock := new(fakeClock)
+	policy := errcodes.RetryPolicy{
+		MaxAttempts: 40,
+		BaseDelay:   time.Hour,
+		MaxDelay:    2 * time.Hour,
+		Clock:       clock,
+		Rand:        func() float64 { return 0.5 },
+	}
+
+	_ = errcodes.Retry(context.Background(), policy, func(ctx context.Context) error {
+		return ErrServiceUnavailable
+	})
+
+	// The backoff is capped at the max delay, and never overflows.
+	for i, d := range clock.delays {
+		want := 90 * time.Minute
+		if i == 0 {
+			want = 45 * time.Minute
+		}
+		if d != want {
+			t.Fatalf("retry %d: want delay %v, got %v", i, want, d)
+		}
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &fakeClock{cancel: cancel}
+	policy := errcodes.RetryPolicy{
+		MaxAttempts: 5,
+		Clock:       clock,
+	}
+
+	var attempts int
+	err := errcodes.Retry(ctx, policy, func(ctx context.Context) error {
+		attempts++
+		return ErrServiceUnavailable
+	})
+
+	if want, got := 1, attempts; want != got {
+		t.Fatalf("want %d attempts, got %d", want, got)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want %v, got %v", context.Canceled, err)
+	}
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("want %v, got %v", ErrServiceUnavailable, err)
+	}
+}
@@ -0,0 +1,114 @@
+package errcodes
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/alextanhongpin/errcodes/stacktrace"
+)
+
+// LogValue satisfies the slog.LogValuer interface.
+func (e *Error) LogValue() slog.Value {
+	return LogValue(e)
+}
+
+// LogValue returns the error as a group of structured log attributes.
+// For a domain error in the error chain, the kind, code, message, internal
+// message, HTTP status, params and details are logged, and the full error
+// string is logged as "error" if it differs from the message. Otherwise only
+// the message is logged.
+// The frames are logged if the error chain has a stack trace.
+func LogValue(err error) slog.Value {
+	if err == nil {
+		return slog.AnyValue(nil)
+	}
+
+	var attrs []slog.Attr
+
+	var ec *Error
+	if errors.As(err, &ec) {
+		attrs = append(attrs,
+			slog.String("kind", string(ec.kind)),
+			slog.String("code", string(ec.code)),
+			slog.String("message", ec.message),
+			slog.Int("http_status", DefaultMapper.HTTPStatusCode(ec)),
+		)
+		if ec.internal != "" {
+			attrs = append(attrs, slog.String("internal", ec.internal))
+		}
+		if ec.params != nil {
+			attrs = append(attrs, slog.Any("params", ec.params))
+		}
+		if len(ec.details) > 0 {
+			attrs = append(attrs, slog.Any("details", ec.details))
+		}
+		if msg := err.Error(); msg != ec.message {
+			attrs = append(attrs, slog.String("error", msg))
+		}
+	} else {
+		attrs = append(attrs, slog.String("message", err.Error()))
+	}
+
+	if frames := stacktrace.StackTrace(err); len(frames) > 0 {
+		attrs = append(attrs, slog.Any("frames", frames))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// NewLogHandler returns a slog.Handler that expands every error-valued
+// attribute with LogValue before passing the record to h.
+func NewLogHandler(h slog.Handler) slog.Handler {
+	return &logHandler{h: h}
+}
+
+type logHandler struct {
+	h slog.Handler
+}
+
+func (l *logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return l.h.Enabled(ctx, level)
+}
+
+func (l *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	res := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		res.AddAttrs(expandAttr(a))
+		return true
+	})
+
+	return l.h.Handle(ctx, res)
+}
+
+func (l *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	res := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		res[i] = expandAttr(a)
+	}
+
+	return &logHandler{h: l.h.WithAttrs(res)}
+}
+
+func (l *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{h: l.h.WithGroup(name)}
+}
+
+func expandAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.Attr{Key: a.Key, Value: LogValue(err)}
+		}
+	case slog.KindGroup:
+		group := a.Value.Group()
+		res := make([]slog.Attr, len(group))
+		for i, g := range group {
+			res[i] = expandAttr(g)
+		}
+
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(res...)}
+	}
+
+	return a
+}
@@ -0,0 +1,19 @@
+package stacktrace_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/errcodes/stacktrace"
+)
+
+func ExampleChain() {
+	err := stacktrace.Wrap(fmt.Errorf("create user: %w", errors.New("connection refused")), "insert")
+	fmt.Println(stacktrace.Chain(err))
+	fmt.Printf("%q\n", err)
+
+	// Output:
+	// create user: connection refused
+	// Caused by: connection refused
+	// "create user: connection refused"
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format satisfies the fmt.Formatter interface.
+// The %+v verb prints the wrap chain followed by the stack trace. Other
+// verbs format the error message.
+func (e *ErrorTrace) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		io.WriteString(s, Chain(e))
+		io.WriteString(s, "\n")
+		io.WriteString(s, Sprint(e, false))
+
+		return
+	}
+
+	fmt.Fprintf(s, fmt.FormatString(s, verb), e.Error())
+}
+
+// Chain returns the wrap chain of the error, one error per line, from the
+// outermost to the innermost.
+// Errors that implement fmt.Stringer are printed with String, and the
+// ErrorTrace wrappers are skipped.
+func Chain(err error) string {
+	var lines []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		if _, ok := err.(*ErrorTrace); ok {
+			continue
+		}
+
+		msg := err.Error()
+		if s, ok := err.(fmt.Stringer); ok {
+			msg = s.String()
+		}
+
+		if len(lines) > 0 {
+			msg = fmt.Sprintf("%s %s", body, msg)
+		}
+		lines = append(lines, msg)
+	}
+
+	return strings.Join(lines, "\n")
+}
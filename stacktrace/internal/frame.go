@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"runtime"
+	"strings"
+)
+
+type Frame struct {
+	ID       int    `json:"id"`
+	Cause    string `json:"cause"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function"`
+}
+
+func StackTrace(err error) []Frame {
+	if err == nil {
+		return nil
+	}
+
+	var res []Frame
+
+	pcs, cause := Unwrap(err)
+	pcs = FilterFrames(pcs)
+
+	var id int
+	frames := runtime.CallersFrames(pcs)
+	for {
+		id++
+		frame, more := frames.Next()
+		if SkipFrame(frame) {
+			if !more {
+				break
+			}
+
+			continue
+		}
+
+		msg, _ := cause[frame.PC+1]
+		res = append(res, Frame{
+			ID:       id,
+			Cause:    msg,
+			File:     frame.File,
+			Function: frame.Function,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+
+	return res
+}
+
+func FilterFrames(pcs []uintptr) []uintptr {
+	var res []uintptr
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		if SkipFrame(f) {
+			if !more {
+				break
+			}
+			continue
+		}
+
+		res = append(res, f.PC+1)
+		if !more {
+			break
+		}
+	}
+
+	return res
+}
+
+func SkipFrame(f runtime.Frame) bool {
+	// Skip empty function.
+	return f.Function == "" ||
+		// Skip runtime and testing package.
+		strings.HasPrefix(f.Function, "runtime") ||
+		strings.HasPrefix(f.Function, "testing") ||
+		strings.HasPrefix(f.Function, "net") ||
+
+		// Skip files with underscore.
+		// e.g. _testmain.go
+		strings.HasPrefix(f.File, "_")
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+)
+
+const indent = "    "
+const head = "Origin is:"
+const tail = "Ends here:"
+const body = "Caused by:"
+
+func Sprint(err error, reversed bool) string {
+	if err == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("Error:")
+	sb.WriteRune(' ')
+	sb.WriteString(err.Error())
+	sb.WriteRune('\n')
+
+	pcs, cause := Unwrap(err)
+	pcs = FilterFrames(pcs)
+	pcs, cause = prettyCause(pcs, cause)
+	if reversed {
+		reverse(pcs)
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if SkipFrame(frame) {
+			if !more {
+				break
+			}
+
+			continue
+		}
+
+		msg, ok := cause[frame.PC+1]
+		if ok && msg != "" {
+			sb.WriteString(indent)
+			sb.WriteString(msg)
+			sb.WriteRune('\n')
+		}
+		sb.WriteString(indent)
+		sb.WriteString(indent)
+		sb.WriteString(formatFrame(frame))
+		if !more {
+			break
+		}
+
+		sb.WriteRune('\n')
+	}
+
+	return sb.String()
+}
+
+func formatFrame(frame runtime.Frame) string {
+	return fmt.Sprintf("at %s (in %s:%d)",
+		prettyFunction(frame.Function),
+		prettyFile(frame.File),
+		frame.Line,
+	)
+}
+
+func prettyFile(f string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return f
+	}
+
+	f = strings.TrimPrefix(f, wd)
+	return strings.TrimPrefix(f, "/")
+}
+
+func prettyFunction(f string) string {
+	_, file := path.Split(f)
+	return file
+}
+
+func prettyCause(pcs []uintptr, cause map[uintptr]string) ([]uintptr, map[uintptr]string) {
+	switch len(pcs) {
+	case 0:
+	case 1:
+	default:
+		pc := pcs[0]
+		// Display the first line as "Origin is:".
+		if msg, ok := cause[pc]; ok {
+			cause[pc] = fmt.Sprintf("%s %s", head, msg)
+		} else {
+			cause[pc] = head
+		}
+
+		// Display the intermediate line as "Caused by:".
+		for pc := range cause {
+			if pc == pcs[0] || pc == pcs[len(pcs)-1] {
+				continue
+			}
+
+			if msg, ok := cause[pc]; ok {
+				cause[pc] = fmt.Sprintf("%s %s", body, msg)
+			}
+		}
+
+		// Display the last line as "Ends here:".
+		pc = pcs[len(pcs)-1]
+		if msg, ok := cause[pc]; ok {
+			cause[pc] = fmt.Sprintf("%s %s", tail, msg)
+		} else {
+			cause[pc] = tail
+		}
+	}
+	return pcs, cause
+}
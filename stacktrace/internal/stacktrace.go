@@ -3,6 +3,7 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"runtime"
 )
 
@@ -116,6 +117,14 @@ func (e *ErrorTrace) Unwrap() error {
 	return e.err
 }
 
+// LogValue satisfies the slog.LogValuer interface.
+func (e *ErrorTrace) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("message", e.Error()),
+		slog.Any("frames", StackTrace(e)),
+	)
+}
+
 func Reverse[T any](s []T) {
 	reverse(s)
 }
@@ -0,0 +1,81 @@
+package errcodes
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+)
+
+// Template renders the params into the message of a sentinel error.
+// The params are usually a struct, e.g.
+//
+//	var ErrUserExists = errcodes.New(errcodes.Exists, "user_exists", "The user account already exists")
+//	var UserExists = errcodes.NewTemplate[struct{ ID string }](ErrUserExists, "The user {{.ID}} already exists")
+type Template[T any] struct {
+	err  *Error
+	tmpl *template.Template
+}
+
+// NewTemplate returns a new template for the sentinel error, using the
+// text/template syntax for the message.
+// It panics if the error is not an *Error, or if the message cannot be
+// parsed.
+func NewTemplate[T any](err error, message string) *Template[T] {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		panic("errcodes: template requires *errcodes.Error")
+	}
+
+	return &Template[T]{
+		err:  ec,
+		tmpl: template.Must(template.New(string(ec.code)).Option("missingkey=error").Parse(message)),
+	}
+}
+
+// New returns a new instance of the sentinel error, with the message
+// rendered from the params.
+// The instance matches the sentinel with errors.Is, and the sentinel is
+// never mutated.
+// If the message cannot be rendered, the sentinel message is used.
+func (t *Template[T]) New(params T) error {
+	err := *t.err
+	err.params = params
+
+	if msg, ok := execute(t.tmpl, params); ok {
+		err.message = msg
+	}
+
+	return &err
+}
+
+// render renders the params into the message, e.g. to render a translated
+// message of a template instance.
+func render(code Code, message string, params any) (string, bool) {
+	tmpl, err := template.New(string(code)).Option("missingkey=error").Parse(message)
+	if err != nil {
+		return "", false
+	}
+
+	return execute(tmpl, params)
+}
+
+func execute(tmpl *template.Template, params any) (string, bool) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, params); err != nil {
+		return "", false
+	}
+
+	return sb.String(), true
+}
+
+// Params returns the params of the template instance in the error chain.
+func (t *Template[T]) Params(err error) (T, bool) {
+	var ec *Error
+	if errors.As(err, &ec) && ec.Is(t.err) {
+		params, ok := ec.params.(T)
+		return params, ok
+	}
+
+	var params T
+	return params, false
+}
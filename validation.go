@@ -0,0 +1,55 @@
+package errcodes
+
+import "errors"
+
+// ErrValidationFailed is the sentinel for the error returned by
+// Validation.Err.
+var ErrValidationFailed = New(BadRequest, "validation_failed", "The request is invalid")
+
+// Validation collects the field violations while validating a request.
+type Validation struct {
+	violations FieldViolations
+}
+
+// Add adds a violation for the field.
+// The field is the path to the field, e.g. "address.postal_code".
+func (v *Validation) Add(field string, code Code, message string) {
+	v.violations = append(v.violations, FieldViolation{
+		Field:       field,
+		Code:        code,
+		Description: message,
+	})
+}
+
+// Violations returns the violations collected so far.
+func (v *Validation) Violations() FieldViolations {
+	return v.violations
+}
+
+// Err returns a single ErrValidationFailed error with all the violations,
+// or nil if there are none.
+func (v *Validation) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return WithDetails(ErrValidationFailed, append(FieldViolations(nil), v.violations...))
+}
+
+// Violations returns the field violations of the domain error in the error
+// chain.
+func Violations(err error) FieldViolations {
+	var ec *Error
+	if !errors.As(err, &ec) {
+		return nil
+	}
+
+	var res FieldViolations
+	for _, d := range ec.details {
+		if fv, ok := d.(FieldViolations); ok {
+			res = append(res, fv...)
+		}
+	}
+
+	return res
+}